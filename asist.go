@@ -1,27 +1,82 @@
 package main
 
 import (
+	"context"
+	"os"
+	"os/signal"
 	"time"
 
+	"github.com/certinia/asist/diff"
 	"github.com/certinia/asist/errorhandler"
+	"github.com/certinia/asist/history"
+	"github.com/certinia/asist/lsp"
 	"github.com/certinia/asist/output"
+	"github.com/certinia/asist/parser/options"
+	"github.com/certinia/asist/precommit"
 	"github.com/certinia/asist/scanner"
+	"github.com/certinia/asist/serve"
+	"github.com/certinia/asist/watch"
 )
 
 func main() {
 	scanTime := output.ScanTime{
 		StartedTime: time.Now().String(),
 	}
+	//Register subcommands, they are executed instead of the scan
+	options.AddCommand(options.Command{
+		Name:             "lsp",
+		ShortDescription: "Run ASIST as a language server",
+		LongDescription:  "Run ASIST as a Language Server Protocol server over stdio. Rules and config are loaded once and open documents are scanned as they are edited.",
+		Data:             &lsp.Command{},
+	})
+	options.AddCommand(options.Command{
+		Name:             "diff",
+		ShortDescription: "Compare the results of two scans",
+		LongDescription:  "Compare the json outputs of two scans and report the new, fixed and persisting findings. Findings are matched by fingerprint.",
+		Data:             &diff.Command{},
+	})
+	options.AddCommand(options.Command{
+		Name:             "watch",
+		ShortDescription: "Scan again on file changes",
+		LongDescription:  "Scan a file or folder, then scan its files again whenever they change and print the findings added and resolved. Ignored files and folders are not watched.",
+		Data:             &watch.Command{},
+	})
+	options.AddCommand(options.Command{
+		Name:             "serve",
+		ShortDescription: "Run ASIST as a local HTTP service",
+		LongDescription:  "Serve a JSON API to scan the content of files (POST /scan), list the rules (GET /rules) and reload the config (POST /reload-config). Rules and config are loaded once, not on each request.",
+		Data:             &serve.Command{},
+	})
+	options.AddCommand(options.Command{
+		Name:             "history",
+		ShortDescription: "Scan the lines added by past commits",
+		LongDescription:  "Scan the lines added by the commits reachable from HEAD, newest first, e.g. to find credentials which remain in the git history. Merge commits are scanned for the lines which are in none of their parents. Each finding is reported with the commit, author and date which introduced it. Use --rules to select the rules to run.",
+		Data:             &history.Command{},
+	})
+	options.AddCommand(options.Command{
+		Name:             "pre-commit",
+		ShortDescription: "Scan the staged changes before a commit",
+		LongDescription:  "Scan the content staged in the git index, not the working tree, with the CI/CD rules of the config and block the commit when a rule exceeds its threshold. Run by the hook written by install-hook.",
+		Data:             &precommit.Command{},
+	})
+	options.AddCommand(options.Command{
+		Name:             "install-hook",
+		ShortDescription: "Install the git pre-commit hook",
+		LongDescription:  "Write a git pre-commit hook running asist pre-commit, with the config file given with -c. An existing hook which was not installed by ASIST is only overwritten with --force.",
+		Data:             &precommit.InstallHookCommand{},
+	})
 	//Load required resources for scan
-	paths, rules, err := scanner.LoadResources()
+	paths, rules, changedLines, err := scanner.LoadResources()
 	if err != nil {
 		errorhandler.ExitWithError(err)
 	}
-	//Run active rules on all files
-	finalResult, err := scanner.RunRulesOnFiles(paths, rules)
+	//Run active rules on all files, stop the scan on interrupt
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt)
+	defer stop()
+	finalResult, err := scanner.RunRulesOnFiles(ctx, paths, rules, changedLines)
 	if err != nil {
 		errorhandler.ExitWithError(err)
 	}
 	scanTime.EndingTime = time.Now().String()
-	output.DisplayOutput(finalResult, &scanTime)
+	output.DisplayOutput(finalResult, rules, &scanTime)
 }
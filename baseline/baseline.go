@@ -0,0 +1,121 @@
+package baseline
+
+import (
+	"encoding/json"
+	"os"
+	"sort"
+
+	"github.com/certinia/asist/errorhandler"
+	"github.com/certinia/asist/files"
+	"github.com/certinia/asist/finding"
+	"github.com/certinia/asist/message"
+	"github.com/certinia/asist/rules"
+)
+
+const version = 1
+
+// Entry is a finding recorded in a baseline file, the file name is relative to the repository root
+type Entry struct {
+	Fingerprint string       `json:"Fingerprint"`
+	RuleID      rules.RuleID `json:"RuleID"`
+	FileName    string       `json:"File"`
+	LineNumber  int          `json:"LineNumber"`
+}
+
+// Baseline is the content of a baseline file, i.e. the findings tolerated as existing debt
+type Baseline struct {
+	Version  int     `json:"Version"`
+	Findings []Entry `json:"Findings"`
+}
+
+// Comparison contains the result of comparing the findings of a scan with a baseline
+type Comparison struct {
+	// New findings which are not in the baseline
+	New []finding.Finding
+	// Fixed baseline entries which were not found anymore
+	Fixed []Entry
+}
+
+/**
+ * Create - method used to create a baseline from the findings of a scan
+ */
+func Create(findings []finding.Finding) *Baseline {
+	entries := []Entry{}
+	for _, result := range findings {
+		entries = append(entries, createEntry(result))
+	}
+	// Sort entries so the baseline file only changes when findings change
+	sort.SliceStable(entries, func(i, j int) bool {
+		if entries[i].FileName != entries[j].FileName {
+			return entries[i].FileName < entries[j].FileName
+		}
+		if entries[i].LineNumber != entries[j].LineNumber {
+			return entries[i].LineNumber < entries[j].LineNumber
+		}
+		return entries[i].Fingerprint < entries[j].Fingerprint
+	})
+	return &Baseline{Version: version, Findings: entries}
+}
+
+/**
+ * Write - method used to write the baseline of the findings into a file
+ */
+func Write(path string, findings []finding.Finding) error {
+	content, err := json.MarshalIndent(Create(findings), "", " ")
+	if err != nil {
+		return errorhandler.NewInternalError(message.GetMarshallingOutputError(err))
+	}
+	if err := os.WriteFile(path, content, 0644); err != nil {
+		return errorhandler.NewUserError(message.GetBaselineWriteError(path, err))
+	}
+	return nil
+}
+
+/**
+ * Read - method used to read a baseline file written by Write.
+ *	Baselines of other versions are rejected, as their fingerprints may be built differently.
+ */
+func Read(path string) (*Baseline, error) {
+	content, err := os.ReadFile(path)
+	if err != nil {
+		return nil, errorhandler.NewUserError(message.GetFileReadError(path, err))
+	}
+	var baseline Baseline
+	if err := json.Unmarshal(content, &baseline); err != nil {
+		return nil, errorhandler.NewUserError(message.GetFileUnmarshalingError(err))
+	}
+	if baseline.Version != version {
+		return nil, errorhandler.NewUserError(message.GetBaselineVersionError(path, baseline.Version, version))
+	}
+	return &baseline, nil
+}
+
+/**
+ * Compare - method used to find the findings which are not in the baseline and the baseline entries which were fixed.
+ *	Entries are matched by fingerprint and each entry can only match one finding, so duplicated findings are counted.
+ */
+func (b *Baseline) Compare(findings []finding.Finding) Comparison {
+	comparison := Comparison{New: []finding.Finding{}, Fixed: []Entry{}}
+	entryFingerprints := make([]string, len(b.Findings))
+	for index, entry := range b.Findings {
+		entryFingerprints[index] = entry.Fingerprint
+	}
+	match := finding.MatchFingerprints(entryFingerprints, finding.GetFingerprints(findings))
+	for _, index := range match.New {
+		comparison.New = append(comparison.New, findings[index])
+	}
+	// Fixed entries keep the order of the baseline file
+	for _, index := range match.Fixed {
+		comparison.Fixed = append(comparison.Fixed, b.Findings[index])
+	}
+	return comparison
+}
+
+func createEntry(result finding.Finding) Entry {
+	return Entry{
+		Fingerprint: result.GetFingerprint(),
+		RuleID:      result.ID,
+		FileName:    files.GetRepoRelativePath(result.Occurrence.FileName),
+		LineNumber:  result.Occurrence.LineNumber,
+	}
+}
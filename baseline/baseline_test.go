@@ -0,0 +1,124 @@
+package baseline
+
+import (
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+
+	"github.com/certinia/asist/finding"
+	"github.com/certinia/asist/rules"
+)
+
+func createFinding(ruleId rules.RuleID, fileName string, lineNumber int) finding.Finding {
+	return finding.Finding{
+		ID: ruleId,
+		Occurrence: rules.Occurrence{
+			FileName:    fileName,
+			LineContent: "content",
+			LineNumber:  lineNumber,
+			ColumnRange: []int{0, 7},
+		},
+	}
+}
+
+func TestWriteAndRead_WhenFindingsExist_ReturnsSortedEntries(t *testing.T) {
+	//Given
+	path := filepath.Join(t.TempDir(), "baseline.json")
+	findings := []finding.Finding{
+		createFinding("XSSLabel", "/src/b.page", 3),
+		createFinding("XSSLabel", "/src/a.page", 9),
+		createFinding("SessionIDApex", "/src/a.page", 2),
+	}
+
+	//When
+	writeErr := Write(path, findings)
+	actualBaseline, readErr := Read(path)
+
+	//Then
+	if writeErr != nil || readErr != nil {
+		t.Fatalf("Should not return any error! write: %v, read: %v", writeErr, readErr)
+	}
+	expectedEntries := []Entry{
+		{Fingerprint: findings[2].CreateFindingID(), RuleID: "SessionIDApex", FileName: "a.page", LineNumber: 2},
+		{Fingerprint: findings[1].CreateFindingID(), RuleID: "XSSLabel", FileName: "a.page", LineNumber: 9},
+		{Fingerprint: findings[0].CreateFindingID(), RuleID: "XSSLabel", FileName: "b.page", LineNumber: 3},
+	}
+	if actualBaseline.Version != version || !reflect.DeepEqual(actualBaseline.Findings, expectedEntries) {
+		t.Errorf("Baseline mismatched. Actual: %+v, Expected: %+v", actualBaseline.Findings, expectedEntries)
+	}
+}
+
+func TestRead_WhenFileNotExist_ReturnsError(t *testing.T) {
+	//When
+	actualBaseline, err := Read(filepath.Join(t.TempDir(), "missing.json"))
+
+	//Then
+	if err == nil || actualBaseline != nil {
+		t.Errorf("Expected error for missing baseline file")
+	}
+}
+
+func TestRead_WhenVersionIsUnknown_ReturnsError(t *testing.T) {
+	//Given
+	path := filepath.Join(t.TempDir(), "baseline.json")
+	os.WriteFile(path, []byte(`{"Version": 2, "Findings": []}`), 0600)
+
+	//When
+	actualBaseline, err := Read(path)
+
+	//Then
+	if err == nil || actualBaseline != nil {
+		t.Errorf("Expected error for unknown baseline version")
+	}
+}
+
+func TestCompare_WhenFindingsAddedAndFixed_ReturnsNewAndFixed(t *testing.T) {
+	//Given
+	existing := createFinding("XSSLabel", "/src/a.page", 1)
+	fixed := createFinding("XSSLabel", "/src/a.page", 2)
+	added := createFinding("SessionIDApex", "/src/a.cls", 5)
+	baseline := Create([]finding.Finding{existing, fixed})
+
+	//When
+	actualResult := baseline.Compare([]finding.Finding{existing, added})
+
+	//Then
+	if !reflect.DeepEqual(actualResult.New, []finding.Finding{added}) {
+		t.Errorf("New findings mismatched. Actual: %+v", actualResult.New)
+	}
+	if len(actualResult.Fixed) != 1 || actualResult.Fixed[0].LineNumber != 2 {
+		t.Errorf("Fixed findings mismatched. Actual: %+v", actualResult.Fixed)
+	}
+}
+
+func TestCompare_WhenDuplicateFindingIncreases_ReturnsExtraFindingAsNew(t *testing.T) {
+	//Given
+	existing := createFinding("XSSLabel", "/src/a.page", 1)
+	baseline := Create([]finding.Finding{existing})
+
+	//When
+	actualResult := baseline.Compare([]finding.Finding{existing, existing})
+
+	//Then
+	if len(actualResult.New) != 1 || len(actualResult.Fixed) != 0 {
+		t.Errorf("Expected one new and no fixed findings. Actual: %+v", actualResult)
+	}
+}
+
+func TestCompare_WhenFindingMovedToAnotherLine_ReturnsNoNewFinding(t *testing.T) {
+	//Given
+	recorded := createFinding("XSSLabel", "/src/a.page", 1)
+	recorded.Fingerprint = "stable"
+	moved := createFinding("XSSLabel", "/src/a.page", 10)
+	moved.Fingerprint = "stable"
+	baseline := Create([]finding.Finding{recorded})
+
+	//When
+	actualResult := baseline.Compare([]finding.Finding{moved})
+
+	//Then
+	if len(actualResult.New) != 0 || len(actualResult.Fixed) != 0 {
+		t.Errorf("Moved finding should match the baseline by fingerprint. Actual: %+v", actualResult)
+	}
+}
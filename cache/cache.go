@@ -0,0 +1,155 @@
+// Package cache stores the findings of the files scanned, so the files which did not change since the previous scan
+// are not scanned again. The cache is invalidated as a whole when the key of the scan changes, i.e. the binary version,
+// the effective rules or the config.
+package cache
+
+import (
+	"crypto/sha256"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sync"
+
+	"github.com/certinia/asist/files"
+	"github.com/certinia/asist/finding"
+)
+
+const (
+	version       = 2
+	cacheFileName = "findings.json"
+)
+
+// Entry is the output of the scan of a file, valid as long as the content of the file has the same hash
+type Entry struct {
+	ContentHash string          `json:"ContentHash"`
+	Output      *finding.Output `json:"Output"`
+	// FalsePositives are the indexes of the results marked as false positive, the flag is not part of the json output
+	FalsePositives []int `json:"FalsePositives,omitempty"`
+}
+
+type cacheFile struct {
+	Version int              `json:"Version"`
+	Key     string           `json:"Key"`
+	Files   map[string]Entry `json:"Files"`
+}
+
+// Cache is the cache of the findings per file, it can be used by several goroutines at once
+type Cache struct {
+	path    string
+	key     string
+	mutex   sync.Mutex
+	entries map[string]Entry
+}
+
+/**
+ * Open - method used to load the cache stored in the directory for the key of the scan.
+ *	A missing or unreadable cache, or a cache created for another key, starts empty.
+ */
+func Open(dir string, key string) *Cache {
+	c := &Cache{path: filepath.Join(dir, cacheFileName), key: key, entries: map[string]Entry{}}
+	content, err := os.ReadFile(c.path)
+	if err != nil {
+		return c
+	}
+	var storedCache cacheFile
+	if err := json.Unmarshal(content, &storedCache); err != nil || storedCache.Version != version || storedCache.Key != key {
+		return c
+	}
+	for fileName, entry := range storedCache.Files {
+		if entry.Output == nil {
+			continue
+		}
+		for _, index := range entry.FalsePositives {
+			if index >= 0 && index < len(entry.Output.Results) {
+				entry.Output.Results[index].Occurrence.IsFalsePositive = true
+			}
+		}
+		c.entries[fileName] = entry
+	}
+	return c
+}
+
+/**
+ * CreateKey - method used to create the key of a scan from the binary version and the settings which change its findings,
+ *	e.g. the metadata of the rules after their overrides and the config
+ */
+func CreateKey(binaryVersion string, settings ...any) (string, error) {
+	hash := sha256.New()
+	hash.Write([]byte(binaryVersion))
+	for _, setting := range settings {
+		content, err := json.Marshal(setting)
+		if err != nil {
+			return "", err
+		}
+		hash.Write([]byte{0})
+		hash.Write(content)
+	}
+	return fmt.Sprintf("%x", hash.Sum(nil)), nil
+}
+
+/**
+ * HashContent - method used to create the hash of the content of a file
+ */
+func HashContent(file *files.File) string {
+	hash := sha256.New()
+	for _, line := range file.Lines {
+		fmt.Fprintf(hash, "%d:%d:%s\n", line.LineNumber, line.ColumnOffset, line.Text)
+	}
+	return fmt.Sprintf("%x", hash.Sum(nil))
+}
+
+/**
+ * Get - method used to get the cached output of a file, only if its content did not change
+ */
+func (c *Cache) Get(fileName string, contentHash string) (*finding.Output, bool) {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+	entry, isCached := c.entries[fileName]
+	if !isCached || entry.ContentHash != contentHash {
+		return nil, false
+	}
+	return entry.Output, true
+}
+
+/**
+ * Put - method used to cache the output of the scan of a file
+ */
+func (c *Cache) Put(fileName string, contentHash string, output *finding.Output) {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+	entry := Entry{ContentHash: contentHash, Output: output}
+	for index, result := range output.Results {
+		if result.Occurrence.IsFalsePositive {
+			entry.FalsePositives = append(entry.FalsePositives, index)
+		}
+	}
+	c.entries[fileName] = entry
+}
+
+/**
+ * Write - method used to store the cache in its directory. The entries of the files which do not exist anymore are dropped.
+ */
+func (c *Cache) Write() error {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+	storedCache := cacheFile{Version: version, Key: c.key, Files: map[string]Entry{}}
+	for fileName, entry := range c.entries {
+		if _, err := os.Stat(fileName); err == nil {
+			storedCache.Files[fileName] = entry
+		}
+	}
+	content, err := json.Marshal(storedCache)
+	if err != nil {
+		return err
+	}
+	if err := os.MkdirAll(filepath.Dir(c.path), 0755); err != nil {
+		return err
+	}
+	// Write into a temporary file first so a scan stopped while writing does not corrupt the cache
+	tempPath := c.path + ".tmp"
+	if err := os.WriteFile(tempPath, content, 0644); err != nil {
+		return err
+	}
+	return os.Rename(tempPath, c.path)
+}
@@ -0,0 +1,95 @@
+package cache
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/certinia/asist/files"
+	"github.com/certinia/asist/finding"
+	"github.com/certinia/asist/rules"
+)
+
+func createCachedCache(t *testing.T) (string, string) {
+	rootPath := t.TempDir()
+	fileName := filepath.Join(rootPath, "Foo.cls")
+	writtenCache := Open(filepath.Join(rootPath, ".asist-cache"), "key")
+	writtenCache.Put(fileName, "hash", &finding.Output{Count: 1, Results: []finding.Finding{{ID: "XSSLabel", Occurrence: rules.Occurrence{FileName: fileName, LineNumber: 3}}}})
+	// Entries of files which do not exist are dropped on write, so the file must exist
+	if err := os.WriteFile(fileName, []byte{}, 0600); err != nil {
+		t.Fatal(err)
+	}
+	if err := writtenCache.Write(); err != nil {
+		t.Fatalf("Should not return error while writing the cache: %v", err)
+	}
+	return rootPath, fileName
+}
+
+func TestOpen_WhenKeyIsTheSame_ReturnsCachedOutput(t *testing.T) {
+	//Given
+	rootPath, fileName := createCachedCache(t)
+
+	//When
+	cachedOutput, isCached := Open(filepath.Join(rootPath, ".asist-cache"), "key").Get(fileName, "hash")
+
+	//Then
+	if !isCached || cachedOutput.Count != 1 || cachedOutput.Results[0].Occurrence.LineNumber != 3 {
+		t.Errorf("Expected the cached output. Actual: %+v", cachedOutput)
+	}
+}
+
+func TestOpen_WhenKeyChanged_ReturnsEmptyCache(t *testing.T) {
+	//Given
+	rootPath, fileName := createCachedCache(t)
+
+	//When
+	_, isCached := Open(filepath.Join(rootPath, ".asist-cache"), "otherKey").Get(fileName, "hash")
+
+	//Then
+	if isCached {
+		t.Errorf("Cache of another key should not be used")
+	}
+}
+
+func TestGet_WhenContentHashChanged_ReturnsNotCached(t *testing.T) {
+	//Given
+	rootPath, fileName := createCachedCache(t)
+
+	//When
+	_, isCached := Open(filepath.Join(rootPath, ".asist-cache"), "key").Get(fileName, "otherHash")
+
+	//Then
+	if isCached {
+		t.Errorf("Output of a changed file should not be cached")
+	}
+}
+
+func TestCreateKey_WhenSettingsChanged_ReturnsDifferentKey(t *testing.T) {
+	//Given
+	metadata := []*rules.RuleMetadata{{ID: "ApexClassNoSharing", Severity: rules.SeverityMedium}}
+	overriddenMetadata := []*rules.RuleMetadata{{ID: "ApexClassNoSharing", Severity: rules.SeverityLow}}
+
+	//When
+	key, _ := CreateKey("1.0.0", metadata)
+	overriddenKey, _ := CreateKey("1.0.0", overriddenMetadata)
+	otherVersionKey, _ := CreateKey("1.0.1", metadata)
+
+	//Then
+	if key == overriddenKey || key == otherVersionKey {
+		t.Errorf("Keys should change with the rules and the version. Actual: %s %s %s", key, overriddenKey, otherVersionKey)
+	}
+}
+
+func TestHashContent_WhenLineChanged_ReturnsDifferentHash(t *testing.T) {
+	//Given
+	file := &files.File{Lines: []files.Line{{LineNumber: 1, Text: "public class Foo {"}}}
+	changedFile := &files.File{Lines: []files.Line{{LineNumber: 1, Text: "public with sharing class Foo {"}}}
+
+	//When
+	hash, changedHash := HashContent(file), HashContent(changedFile)
+
+	//Then
+	if hash == changedHash {
+		t.Errorf("Hash should change with the content")
+	}
+}
@@ -77,15 +77,17 @@ func getStandardRules(ruleMap RuleData) {
 }
 
 func addRuleMappingToFile(file *jen.File, ruleMap RuleData) {
+	// Map rule IDs to constructors so every scan gets its own rule instances, which can be overridden independently
 	file.Var().Id("ruleMapping").Op("=").Map(
 		jen.Qual(RULES_PKG, "RuleID"),
-	).Qual(RULES_PKG, "Rule").BlockFunc(func(g *jen.Group) {
+	).Func().Params().Qual(RULES_PKG, "Rule").BlockFunc(func(g *jen.Group) {
 		for pkg, ruleList := range ruleMap {
 			for _, rule := range ruleList {
 				g.Qual(STANDARD_PKG+pkg, rule+"RuleID").
 					Op(":").
-					Qual(STANDARD_PKG+pkg, "New"+rule+"Rule").
-					Call().Op(",")
+					Func().Params().Qual(RULES_PKG, "Rule").Block(
+					jen.Return(jen.Qual(STANDARD_PKG+pkg, "New"+rule+"Rule").Call()),
+				).Op(",")
 			}
 		}
 	})
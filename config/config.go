@@ -6,6 +6,7 @@ import (
 	"os"
 	"path/filepath"
 	"regexp"
+	"slices"
 
 	"gopkg.in/yaml.v3"
 
@@ -49,22 +50,41 @@ func GetConfigInstance() *Config {
 }
 
 /**
- * ParseConfig - This method reads and parses a configuration file available in either YAML or JSON format.
+ * ParseConfig - This method reads and parses a configuration file available in either YAML or JSON format,
+ *	and stores it as the config instance.
  */
 func ParseConfig(path string) (*Config, error) {
+	parsedConfig, err := Load(path)
+	if err != nil {
+		return nil, err
+	}
+	config = parsedConfig
+	return config, nil
+}
+
+/**
+ * Load - This method reads and parses a configuration file available in either YAML or JSON format, without storing it as the config instance.
+ */
+func Load(path string) (*Config, error) {
 	if path == "" {
 		return nil, nil
 	}
 	fileExt := filepath.Ext(path)
 
+	var parsedConfig *Config
+	var err error
 	switch fileExt {
 	case ".json":
-		return config, parseJSON(path)
+		err = parseJSON(path, &parsedConfig)
 	case ".yaml":
-		return config, parseYAML(path)
+		err = parseYAML(path, &parsedConfig)
 	default:
 		return nil, errorhandler.NewUserError(message.GetInvalidConfigFileError(path))
 	}
+	if err != nil {
+		return nil, err
+	}
+	return parsedConfig, nil
 }
 
 /**
@@ -79,15 +99,23 @@ func GetConfigFilePath(rootPath string, configFilePath string, isBaselineEnabled
 		return "", err
 	}
 	if isDir {
-		if utils.IsFileExists(rootPath + YAML_CONFIG_FILE_PATH) {
-			configFilePath = rootPath + YAML_CONFIG_FILE_PATH
-		} else if utils.IsFileExists(rootPath + JSON_CONFIG_FILE_PATH) {
-			configFilePath = rootPath + JSON_CONFIG_FILE_PATH
-		}
+		configFilePath = FindConfigFile(rootPath)
 	}
 	return configFilePath, nil
 }
 
+/**
+ *	FindConfigFile - Returns the path of the YAML or JSON config file at the root of a folder if it exists, otherwise an empty string.
+ */
+func FindConfigFile(rootPath string) string {
+	if utils.IsFileExists(rootPath + YAML_CONFIG_FILE_PATH) {
+		return rootPath + YAML_CONFIG_FILE_PATH
+	} else if utils.IsFileExists(rootPath + JSON_CONFIG_FILE_PATH) {
+		return rootPath + JSON_CONFIG_FILE_PATH
+	}
+	return ""
+}
+
 /**
  *	FilterExcludedFilesAndFolders - Filter out the paths from the provided list that are present in the ExcludeFilesAndFolders property of config file.
  */
@@ -115,6 +143,7 @@ func (c *Config) GetCustomRuleIds() []rules.RuleID {
 		for customRuleId := range c.CustomRegexRules {
 			customRuleIds = append(customRuleIds, rules.RuleID(customRuleId))
 		}
+		slices.Sort(customRuleIds)
 	}
 	return customRuleIds
 }
@@ -204,19 +233,20 @@ func (c *Config) GetEnabledCustomRuleIds() []rules.RuleID {
 			enabledRuleIds = append(enabledRuleIds, rules.RuleID(customRuleId))
 		}
 	}
+	slices.Sort(enabledRuleIds)
 	return enabledRuleIds
 }
 
 /**
  * parseJSON - Reads and parses the provided JSON configuration file into the corresponding Config structure.
  */
-func parseJSON(path string) error {
+func parseJSON(path string, parsedConfig **Config) error {
 	fileContent, fileError := readFile(path)
 	if fileError != nil {
 		return errorhandler.NewInternalError(message.GetInvalidTemplateFileError(fileError))
 	}
 
-	fileUnmarshalError := json.Unmarshal(fileContent, &config)
+	fileUnmarshalError := json.Unmarshal(fileContent, parsedConfig)
 	if fileUnmarshalError != nil {
 		return errorhandler.NewInternalError(message.GetFileUnmarshalingError(fileUnmarshalError))
 	}
@@ -226,13 +256,13 @@ func parseJSON(path string) error {
 /**
  * parseYAML - Reads and parses the provided YAML configuration file into the corresponding Config structure.
  */
-func parseYAML(path string) error {
+func parseYAML(path string, parsedConfig **Config) error {
 	fileContent, fileError := readFile(path)
 	if fileError != nil {
 		return errorhandler.NewInternalError(message.GetInvalidTemplateFileError(fileError))
 	}
 
-	fileUnmarshalError := yaml.Unmarshal(fileContent, &config)
+	fileUnmarshalError := yaml.Unmarshal(fileContent, parsedConfig)
 	if fileUnmarshalError != nil {
 		return errorhandler.NewInternalError(message.GetFileUnmarshalingError(fileUnmarshalError))
 	}
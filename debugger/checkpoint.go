@@ -3,12 +3,16 @@ package debugger
 import (
 	"log"
 	"os"
+	"sync"
 	"time"
 )
 
 var DebugFunction = func(string) {}
 var refTime = time.Now()
 
+// Files are scanned in parallel so debug messages and the reference time are guarded
+var refTimeMutex sync.Mutex
+
 var logger = log.New(os.Stderr, "\033[0;34mDEBUG\033[0m: ", log.Ldate|log.Ltime)
 
 func setReferenceTime() {
@@ -25,6 +29,8 @@ func Debug(desc string) {
 
 // This method is set as the debug function if debugging is enabled
 func debug(desc string) {
+	refTimeMutex.Lock()
+	defer refTimeMutex.Unlock()
 	logger.Printf("+%dms elapsed [%s]\n", time.Since(refTime).Milliseconds(), desc)
 	setReferenceTime()
 }
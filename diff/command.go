@@ -0,0 +1,64 @@
+package diff
+
+import (
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/certinia/asist/errorhandler"
+	"github.com/certinia/asist/finding"
+	"github.com/certinia/asist/message"
+	"github.com/certinia/asist/output"
+	"github.com/certinia/asist/parser/options"
+	"github.com/certinia/asist/rules"
+)
+
+// Command is the asist diff subcommand, it compares the json outputs of two scans
+type Command struct {
+	FailOn []string `long:"fail-on" choice:"Critical" choice:"High" choice:"Medium" choice:"Low" description:"Exit with code 1 when new findings of this severity appear. Can be repeated"`
+	Args   struct {
+		Old string `positional-arg-name:"old.json" description:"JSON output of the old scan"`
+		New string `positional-arg-name:"new.json" description:"JSON output of the new scan"`
+	} `positional-args:"yes" required:"yes"`
+}
+
+/**
+ * Execute - method used to write the new, fixed and persisting findings between two scans in the output formats
+ */
+func (c *Command) Execute(args []string) error {
+	if err := options.SetupOutputTargets(); err != nil {
+		return err
+	}
+	oldOutput, err := Read(c.Args.Old)
+	if err != nil {
+		return err
+	}
+	newOutput, err := Read(c.Args.New)
+	if err != nil {
+		return err
+	}
+	diffOutput := Compare(oldOutput.Results, newOutput.Results)
+	output.DisplayDiffOutput(diffOutput)
+
+	if failingCount := countFindingsWithSeverity(diffOutput.New, c.FailOn); failingCount > 0 {
+		fmt.Fprintf(os.Stderr, "%s\n", message.GetDiffFailOnError(failingCount, strings.Join(c.FailOn, ", ")))
+		os.Exit(int(errorhandler.ExitCodeOccurrence))
+	}
+	return nil
+}
+
+/**
+ * countFindingsWithSeverity - method used to count the findings which have one of the severities
+ */
+func countFindingsWithSeverity(findings []finding.Finding, severities []string) int {
+	count := 0
+	for _, result := range findings {
+		for _, severity := range severities {
+			if result.Severity == rules.Severity(severity) {
+				count++
+				break
+			}
+		}
+	}
+	return count
+}
@@ -0,0 +1,45 @@
+package diff
+
+import (
+	"encoding/json"
+	"os"
+
+	"github.com/certinia/asist/errorhandler"
+	"github.com/certinia/asist/finding"
+	"github.com/certinia/asist/message"
+)
+
+/**
+ * Read - method used to read the json output of a scan
+ */
+func Read(path string) (*finding.Output, error) {
+	content, err := os.ReadFile(path)
+	if err != nil {
+		return nil, errorhandler.NewUserError(message.GetFileReadError(path, err))
+	}
+	var scanOutput finding.Output
+	if err := json.Unmarshal(content, &scanOutput); err != nil {
+		return nil, errorhandler.NewUserError(message.GetDiffInputError(path, err))
+	}
+	return &scanOutput, nil
+}
+
+/**
+ * Compare - method used to find the new, fixed and persisting findings between an old and a new scan.
+ *	Findings are matched by fingerprint and each old finding can only match one new finding, so duplicated findings are counted.
+ *	Persisting findings are reported at their location in the new scan.
+ */
+func Compare(oldFindings []finding.Finding, newFindings []finding.Finding) *finding.DiffOutput {
+	diffOutput := &finding.DiffOutput{New: []finding.Finding{}, Fixed: []finding.Finding{}, Persisting: []finding.Finding{}}
+	match := finding.MatchFingerprints(finding.GetFingerprints(oldFindings), finding.GetFingerprints(newFindings))
+	for _, index := range match.New {
+		diffOutput.New = append(diffOutput.New, newFindings[index])
+	}
+	for _, index := range match.Persisting {
+		diffOutput.Persisting = append(diffOutput.Persisting, newFindings[index])
+	}
+	for _, index := range match.Fixed {
+		diffOutput.Fixed = append(diffOutput.Fixed, oldFindings[index])
+	}
+	return diffOutput
+}
@@ -0,0 +1,61 @@
+package diff
+
+import (
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+
+	"github.com/certinia/asist/finding"
+	"github.com/certinia/asist/rules"
+)
+
+func createFinding(ruleId rules.RuleID, fingerprint string, lineNumber int) finding.Finding {
+	return finding.Finding{
+		ID:          ruleId,
+		Fingerprint: fingerprint,
+		Occurrence:  rules.Occurrence{FileName: "/src/a.cls", LineNumber: lineNumber},
+	}
+}
+
+func TestCompare_WhenFindingsChanged_ReturnsNewFixedAndPersistingFindings(t *testing.T) {
+	//Given
+	oldFindings := []finding.Finding{
+		createFinding("XSSLabel", "fixed", 1),
+		createFinding("XSSLabel", "persisting", 2),
+		createFinding("SessionIDApex", "duplicated", 3),
+	}
+	newFindings := []finding.Finding{
+		createFinding("XSSLabel", "persisting", 12),
+		createFinding("SessionIDApex", "duplicated", 13),
+		createFinding("SessionIDApex", "duplicated", 14),
+		createFinding("ApexClassNoSharing", "new", 15),
+	}
+
+	//When
+	diffOutput := Compare(oldFindings, newFindings)
+
+	//Then
+	expected := &finding.DiffOutput{
+		New:        []finding.Finding{newFindings[2], newFindings[3]},
+		Fixed:      []finding.Finding{oldFindings[0]},
+		Persisting: []finding.Finding{newFindings[0], newFindings[1]},
+	}
+	if !reflect.DeepEqual(diffOutput, expected) {
+		t.Errorf("Diff mismatched.\n Actual %+v\n Expected %+v", diffOutput, expected)
+	}
+}
+
+func TestRead_WhenFileIsNotScanOutput_ReturnsError(t *testing.T) {
+	//Given
+	path := filepath.Join(t.TempDir(), "baseline.json")
+	os.WriteFile(path, []byte(`[{"RecordType": "Finding"}]`), 0644)
+
+	//When
+	_, err := Read(path)
+
+	//Then
+	if err == nil {
+		t.Errorf("Should return error for a file which is not the json output of a scan")
+	}
+}
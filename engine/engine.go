@@ -0,0 +1,469 @@
+// Package engine runs ASIST scans in-process. Unlike the command line scanner it does not read the command line
+// options, does not exit the process and does not use the config instance, so several scanners can be used at once.
+package engine
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io/fs"
+	"path/filepath"
+	"runtime"
+	"sync"
+	"time"
+
+	"github.com/certinia/asist/cache"
+	"github.com/certinia/asist/config"
+	"github.com/certinia/asist/debugger"
+	"github.com/certinia/asist/errorhandler"
+	"github.com/certinia/asist/files"
+	"github.com/certinia/asist/files/gitdiff"
+	"github.com/certinia/asist/finding"
+	"github.com/certinia/asist/message"
+	"github.com/certinia/asist/regexrulehelper"
+	"github.com/certinia/asist/rules"
+	"github.com/certinia/asist/ruleset"
+)
+
+// ScanOptions are the options of a scan, the zero value runs the rules enabled in the config on all files
+type ScanOptions struct {
+	// Rules to run instead of the rules enabled in the config
+	Rules []rules.RuleID
+	// CICDScan only runs the CI/CD rules of the config
+	CICDScan bool
+	// BaselineScan runs all rules, ignores severity overrides and also reports the occurrences marked as false positive
+	BaselineScan bool
+	// Jobs is the number of files scanned in parallel, defaults to the number of CPUs
+	Jobs int
+	// Since only reports the findings on lines changed in the working tree since this git ref
+	Since string
+	// FileTimeout is the maximum time spent running the rules on a single file, no limit when zero.
+	// The rules not run on a file which timed out are reported in the diagnostics of the output
+	FileTimeout time.Duration
+	// ParseOptions split the content of the files into lines, e.g. to chunk the long lines of minified files
+	ParseOptions files.ParseOptions
+	// ReadFile reads a file to scan with the parse options, defaults to files.ReadWithOptions
+	ReadFile func(path string, parseOptions files.ParseOptions) (*files.File, error)
+	// Cache holds the findings of the files scanned previously, the files whose content did not change are not scanned again.
+	// Its key must change with the rules, the config and the options above
+	Cache *cache.Cache
+}
+
+// Scanner runs a set of rules on files, it can be used by several goroutines at once
+type Scanner struct {
+	config  *config.Config
+	options ScanOptions
+	rules   []*rules.Rule
+	// ruleSlots bounds the rules running in their own goroutine, including the rules still running after their file timed out
+	ruleSlots chan struct{}
+}
+
+/**
+ * NewScanner - method used to create a scanner running the rules selected by the config and the scan options
+ */
+func NewScanner(cfg *config.Config, opts ScanOptions) (*Scanner, error) {
+	selection := ruleset.RuleSelection{Rules: opts.Rules, CICDScan: opts.CICDScan, BaselineScan: opts.BaselineScan}
+	standardRuleIds, customRuleIds, err := ruleset.GetRuleIdsToRun(cfg, selection)
+	if err != nil {
+		return nil, err
+	}
+	ruleInstances, err := ruleset.CreateAndOverrideRules(standardRuleIds, customRuleIds, cfg, opts.BaselineScan)
+	if err != nil {
+		return nil, err
+	}
+	return NewScannerWithRules(cfg, ruleInstances, opts), nil
+}
+
+/**
+ * NewScannerWithRules - method used to create a scanner running the given rules, the rules selected by the scan options are ignored
+ */
+func NewScannerWithRules(cfg *config.Config, ruleInstances []*rules.Rule, opts ScanOptions) *Scanner {
+	if opts.Jobs <= 0 {
+		opts.Jobs = runtime.NumCPU()
+	}
+	if opts.ReadFile == nil {
+		opts.ReadFile = files.ReadWithOptions
+	}
+	// Each worker may run a rule while a rule it abandoned after a file timeout is still running
+	return &Scanner{config: cfg, options: opts, rules: ruleInstances, ruleSlots: make(chan struct{}, 2*opts.Jobs)}
+}
+
+/**
+ * Rules - method used to get the rules run by the scanner
+ */
+func (s *Scanner) Rules() []*rules.Rule {
+	return s.rules
+}
+
+/**
+ * ParseOptions - method used to get the options splitting the content of the files scanned into lines,
+ *	e.g. to parse content which is not read by the scanner
+ */
+func (s *Scanner) ParseOptions() files.ParseOptions {
+	return s.options.ParseOptions
+}
+
+/**
+ * Scan - method used to scan the files and folders at the given paths, honouring the ignore files and the
+ *	excluded files and folders of the config
+ */
+func (s *Scanner) Scan(ctx context.Context, paths []string) (*finding.Output, error) {
+	filePaths, changedLines, err := ListFiles(s.config, paths, s.options.Since)
+	if err != nil {
+		return nil, err
+	}
+	finalResult, err := s.ScanFiles(ctx, filePaths)
+	if err != nil || changedLines == nil {
+		return finalResult, err
+	}
+	return FilterChangedFindings(finalResult, changedLines), nil
+}
+
+/**
+ * ListFiles - method used to list the files to scan at the given paths. When since is set, only the files changed
+ *	since that git ref are listed and their changed lines are returned.
+ */
+func ListFiles(cfg *config.Config, paths []string, since string) ([]string, gitdiff.ChangedLines, error) {
+	var filePaths []string
+	var changedLines gitdiff.ChangedLines
+	for _, path := range paths {
+		path, err := filepath.Abs(path)
+		if err != nil {
+			return nil, nil, errorhandler.NewUserError(message.GetPathFetchingError(err))
+		}
+		fileOptions := files.FileOptions{
+			RootPath:        path,
+			DontForceIgnore: cfg != nil && cfg.DontForceIgnore,
+			DontGitIgnore:   cfg != nil && cfg.DontGitIgnore,
+		}
+		pathFiles, err := files.GetAllFilePaths(fileOptions)
+		if err != nil {
+			return nil, nil, err
+		}
+		// Excludes files and folders using yaml feature 'excludefilesandfolders'
+		pathFiles = cfg.FilterExcludedFilesAndFolders(pathFiles)
+		if since != "" {
+			pathChangedLines, err := gitdiff.GetChangedLines(path, since, pathFiles)
+			if err != nil {
+				return nil, nil, err
+			}
+			if changedLines == nil {
+				changedLines = gitdiff.ChangedLines{}
+			}
+			for changedPath, lineRanges := range pathChangedLines {
+				changedLines[changedPath] = lineRanges
+			}
+			pathFiles = pathChangedLines.FilterPaths(pathFiles)
+		}
+		filePaths = append(filePaths, pathFiles...)
+	}
+	return filePaths, changedLines, nil
+}
+
+/**
+ * FilterChangedFindings - method used to drop the findings which are not on a changed line
+ */
+func FilterChangedFindings(finalResult *finding.Output, changedLines gitdiff.ChangedLines) *finding.Output {
+	changedFindings := []finding.Finding{}
+	for _, result := range finalResult.Results {
+		if changedLines.Contains(result.Occurrence.FileName, result.Occurrence.LineNumber) {
+			changedFindings = append(changedFindings, result)
+		}
+	}
+	return &finding.Output{Count: len(changedFindings), Results: changedFindings, Diagnostics: finalResult.Diagnostics}
+}
+
+/**
+ * AddBlame - method used to set the commit which last modified the line of each finding, from the git blame of the repository
+ *	containing the root path. The findings on lines which are not committed yet have no commit.
+ *	Files are blamed in parallel, one file per CPU at a time.
+ */
+func AddBlame(findings []finding.Finding, rootPath string) error {
+	blamer, err := gitdiff.NewBlamer(rootPath)
+	if err != nil {
+		return err
+	}
+	findingIndexesPerFile := map[string][]int{}
+	fileNames := []string{}
+	for index, result := range findings {
+		fileName := result.Occurrence.FileName
+		if _, isListed := findingIndexesPerFile[fileName]; !isListed {
+			fileNames = append(fileNames, fileName)
+		}
+		findingIndexesPerFile[fileName] = append(findingIndexesPerFile[fileName], index)
+	}
+
+	errorsPerFile := make([]error, len(fileNames))
+	fileIndexes := make(chan int)
+	var waitGroup sync.WaitGroup
+	for range min(runtime.NumCPU(), len(fileNames)) {
+		waitGroup.Add(1)
+		go func() {
+			defer waitGroup.Done()
+			for fileIndex := range fileIndexes {
+				errorsPerFile[fileIndex] = addFileBlame(blamer, findings, findingIndexesPerFile[fileNames[fileIndex]])
+			}
+		}()
+	}
+	for fileIndex := range fileNames {
+		fileIndexes <- fileIndex
+	}
+	close(fileIndexes)
+	waitGroup.Wait()
+	for _, err := range errorsPerFile {
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+/**
+ * addFileBlame - method used to set the commit of the findings of a single file, given by their indexes
+ */
+func addFileBlame(blamer *gitdiff.Blamer, findings []finding.Finding, findingIndexes []int) error {
+	for _, index := range findingIndexes {
+		blameLine, err := blamer.GetLine(findings[index].Occurrence.FileName, findings[index].Occurrence.LineNumber)
+		if err != nil {
+			return err
+		}
+		if blameLine != nil {
+			findings[index].Commit = &finding.Commit{Hash: blameLine.Hash, Author: blameLine.Author, Email: blameLine.Email, Date: blameLine.Date}
+		}
+	}
+	return nil
+}
+
+/**
+ * ScanFiles - method used to run the rules on the given files.
+ *	Files are scanned by a bounded pool of workers, findings are collected per file index
+ *	so the order of the results always follows the order of filePaths.
+ */
+func (s *Scanner) ScanFiles(ctx context.Context, filePaths []string) (*finding.Output, error) {
+	var finalResult finding.Output
+	if len(filePaths) == 0 {
+		finalResult.Results = []finding.Finding{}
+		return &finalResult, nil
+	}
+
+	outputPerFile := make([]*finding.Output, len(filePaths))
+	errorsPerFile := make([]error, len(filePaths))
+	fileIndexes := make(chan int)
+	var waitGroup sync.WaitGroup
+
+	workers := min(s.options.Jobs, len(filePaths))
+	debugger.Debug(fmt.Sprintf("scanning %d files using %d workers", len(filePaths), workers))
+	for range workers {
+		waitGroup.Add(1)
+		go func() {
+			defer waitGroup.Done()
+			for index := range fileIndexes {
+				outputPerFile[index], errorsPerFile[index] = s.scanPath(ctx, filePaths[index])
+			}
+		}()
+	}
+	for index := range filePaths {
+		if ctx.Err() != nil {
+			break
+		}
+		fileIndexes <- index
+	}
+	close(fileIndexes)
+	waitGroup.Wait()
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+
+	allFindings := []finding.Finding{}
+	for index := range filePaths {
+		if errorsPerFile[index] != nil {
+			return nil, errorsPerFile[index]
+		}
+		if outputPerFile[index] != nil {
+			allFindings = append(allFindings, outputPerFile[index].Results...)
+			finalResult.Diagnostics = append(finalResult.Diagnostics, outputPerFile[index].Diagnostics...)
+		}
+	}
+	finalResult.Count = len(allFindings)
+	finalResult.Results = allFindings
+	return &finalResult, nil
+}
+
+/**
+ * ScanFile - method used to run the rules eligible for a file on its content already loaded in memory,
+ *	e.g. the unsaved content of a file opened in an editor
+ */
+func (s *Scanner) ScanFile(ctx context.Context, fileMaster *files.File) *finding.Output {
+	return s.runRulesOnFileContent(ctx, s.getValidRulesForFile(fileMaster.FileName), fileMaster)
+}
+
+/**
+ * scanPath - method used to read a file and run the rules eligible for its path on it
+ */
+func (s *Scanner) scanPath(ctx context.Context, path string) (*finding.Output, error) {
+	debugger.Debug(fmt.Sprintf("checking if eligible to scan file %s", path))
+	rulesToRun := s.getValidRulesForFile(path)
+	if len(rulesToRun) == 0 {
+		debugger.Debug(fmt.Sprintf("file is not eligible to scan for enabled rules %s", path))
+		return nil, nil
+	}
+	debugger.Debug(fmt.Sprintf("file is eligible to scan for enabled rules %s", path))
+	fileMaster, err := s.options.ReadFile(path, s.options.ParseOptions)
+	if err != nil {
+		if errors.Is(err, fs.ErrNotExist) {
+			return nil, nil
+		}
+		return nil, errorhandler.NewInternalError(message.GetFileReadError(path, err))
+	}
+	debugger.Debug(fmt.Sprintf("read file %s into memory", path))
+	// Files which could not be fully read are always scanned again
+	if s.options.Cache == nil || fileMaster.ReadError != nil {
+		return s.runRulesOnFileContent(ctx, rulesToRun, fileMaster), nil
+	}
+	contentHash := cache.HashContent(fileMaster)
+	if cachedOutput, isCached := s.options.Cache.Get(path, contentHash); isCached {
+		debugger.Debug(fmt.Sprintf("found findings of unchanged file %s in cache", path))
+		return cachedOutput, nil
+	}
+	fileOutput := s.runRulesOnFileContent(ctx, rulesToRun, fileMaster)
+	if !hasTimeoutDiagnostic(fileOutput) && ctx.Err() == nil {
+		s.options.Cache.Put(path, contentHash, fileOutput)
+	}
+	return fileOutput, nil
+}
+
+/**
+ * hasTimeoutDiagnostic - method used to check if some rules were not run on a file because of the file timeout
+ */
+func hasTimeoutDiagnostic(fileOutput *finding.Output) bool {
+	for _, diagnostic := range fileOutput.Diagnostics {
+		if diagnostic.Reason == finding.DiagnosticReasonTimeout {
+			return true
+		}
+	}
+	return false
+}
+
+/**
+ * runRulesOnFileContent - method used to run the rules on a file loaded in memory and returns its findings.
+ *	When the file timeout is reached, the findings of the rules already run are kept and the timeout is reported in the diagnostics.
+ */
+func (s *Scanner) runRulesOnFileContent(ctx context.Context, rulesToRun []*rules.Rule, fileMaster *files.File) *finding.Output {
+	fileMaster.IncludeFalsePositives = s.options.BaselineScan
+	fileOutput := &finding.Output{Results: []finding.Finding{}, Diagnostics: getReadDiagnostics(fileMaster, s.options.ParseOptions)}
+	fileCtx := ctx
+	if s.options.FileTimeout > 0 {
+		var cancel context.CancelFunc
+		fileCtx, cancel = context.WithTimeout(ctx, s.options.FileTimeout)
+		defer cancel()
+	}
+	for _, rule := range rulesToRun {
+		ruleMetadata := (*rule).GetMetadata()
+		//Search result in master file using pattern(Regex)
+		occurrences, isCompleted := s.runRule(fileCtx, rule, *fileMaster)
+		if !isCompleted {
+			// The whole scan is canceled, the file does not need a diagnostic
+			if ctx.Err() == nil {
+				fileOutput.Diagnostics = append(fileOutput.Diagnostics, finding.Diagnostic{
+					FileName: fileMaster.FileName,
+					RuleID:   ruleMetadata.ID,
+					Status:   finding.DiagnosticStatusSkipped,
+					Reason:   finding.DiagnosticReasonTimeout,
+					Message:  message.GetFileTimeoutDiagnostic(string(ruleMetadata.ID), s.options.FileTimeout),
+				})
+			}
+			debugger.Debug(fmt.Sprintf("stopped running rules on %s at rule %s", fileMaster.FileName, ruleMetadata.ID))
+			break
+		}
+		for _, occurrence := range occurrences {
+			// Column ranges of the chunks of a long line are relative to the chunk
+			if occurrence.ColumnOffset != 0 && len(occurrence.ColumnRange) > 0 {
+				columnRange := make([]int, len(occurrence.ColumnRange))
+				for index, column := range occurrence.ColumnRange {
+					columnRange[index] = column + occurrence.ColumnOffset
+				}
+				occurrence.ColumnRange = columnRange
+			}
+			fileOutput.Results = append(fileOutput.Results, finding.Finding{
+				Occurrence:   occurrence,
+				ID:           ruleMetadata.ID,
+				Name:         ruleMetadata.Name,
+				Description:  ruleMetadata.Description,
+				Severity:     ruleMetadata.Severity,
+				RuleCategory: ruleMetadata.RuleCategory,
+			})
+		}
+		debugger.Debug(fmt.Sprintf("ran rule %s on %s", ruleMetadata.ID, fileMaster.FileName))
+	}
+	finding.SetFingerprints(fileOutput.Results, fileMaster)
+	fileOutput.Count = len(fileOutput.Results)
+	return fileOutput
+}
+
+/**
+ * getReadDiagnostics - method used to report the content of a file which is not scanned, because of truncated lines or a read error
+ */
+func getReadDiagnostics(fileMaster *files.File, parseOptions files.ParseOptions) []finding.Diagnostic {
+	var diagnostics []finding.Diagnostic
+	if len(fileMaster.TruncatedLines) > 0 {
+		diagnostics = append(diagnostics, finding.Diagnostic{
+			FileName: fileMaster.FileName,
+			Status:   finding.DiagnosticStatusTruncated,
+			Reason:   finding.DiagnosticReasonLineTooLong,
+			Message:  message.GetTruncatedLinesDiagnostic(len(fileMaster.TruncatedLines), parseOptions.MaxLineLength, fileMaster.TruncatedLines[0]),
+		})
+	}
+	if fileMaster.ReadError != nil {
+		diagnostics = append(diagnostics, finding.Diagnostic{
+			FileName: fileMaster.FileName,
+			Status:   finding.DiagnosticStatusPartial,
+			Reason:   finding.DiagnosticReasonReadError,
+			Message:  message.GetPartialReadDiagnostic(len(fileMaster.Lines), fileMaster.ReadError),
+		})
+	}
+	return diagnostics
+}
+
+/**
+ * runRule - method used to run a rule on a file and returns false if ctx is done before the rule completes.
+ *	With a file timeout, the rule runs in its own goroutine and is abandoned when ctx is done. Go cannot stop a goroutine,
+ *	so the abandoned rule keeps running until it checks ctx: the standard and custom rules check it between lines, but a
+ *	single regular expression run on a huge line or file still completes. Use --max-line-length to bound this work.
+ *	The rules running in their own goroutine are capped by ruleSlots, once the cap is reached by abandoned rules the next
+ *	rules wait for a slot until their own file timeout.
+ */
+func (s *Scanner) runRule(ctx context.Context, rule *rules.Rule, fileToScan files.File) ([]rules.Occurrence, bool) {
+	if s.options.FileTimeout <= 0 {
+		occurrences := (*rule).Run(ctx, fileToScan)
+		return occurrences, ctx.Err() == nil
+	}
+	select {
+	case s.ruleSlots <- struct{}{}:
+	case <-ctx.Done():
+		return nil, false
+	}
+	result := make(chan []rules.Occurrence, 1)
+	go func() {
+		defer func() { <-s.ruleSlots }()
+		result <- (*rule).Run(ctx, fileToScan)
+	}()
+	select {
+	case occurrences := <-result:
+		return occurrences, ctx.Err() == nil
+	case <-ctx.Done():
+		return nil, false
+	}
+}
+
+/**
+ * getValidRulesForFile - method used to get the rules whose include and exclude patterns match the file path
+ */
+func (s *Scanner) getValidRulesForFile(path string) []*rules.Rule {
+	rulesToRun := []*rules.Rule{}
+	for _, rule := range s.rules {
+		if regexrulehelper.RunIncludeExcludePatternsOnFile(path, *(*rule).GetMetadata()) {
+			rulesToRun = append(rulesToRun, rule)
+		}
+	}
+	return rulesToRun
+}
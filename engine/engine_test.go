@@ -0,0 +1,387 @@
+package engine
+
+import (
+	"context"
+	"errors"
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/certinia/asist/cache"
+	"github.com/certinia/asist/config"
+	"github.com/certinia/asist/files"
+	"github.com/certinia/asist/finding"
+	"github.com/certinia/asist/rules"
+)
+
+type fileNameRule struct {
+	metadata rules.RuleMetadata
+}
+
+func (r *fileNameRule) GetMetadata() *rules.RuleMetadata {
+	return &r.metadata
+}
+
+func (r *fileNameRule) Run(ctx context.Context, fileToScan files.File) []rules.Occurrence {
+	return []rules.Occurrence{{FileName: fileToScan.FileName, LineNumber: len(fileToScan.Lines)}}
+}
+
+// blockingRule never completes on its own, like a pathological regex on a huge file
+type blockingRule struct {
+	metadata rules.RuleMetadata
+}
+
+func (r *blockingRule) GetMetadata() *rules.RuleMetadata {
+	return &r.metadata
+}
+
+func (r *blockingRule) Run(ctx context.Context, fileToScan files.File) []rules.Occurrence {
+	<-ctx.Done()
+	return []rules.Occurrence{{FileName: fileToScan.FileName, LineNumber: 1}}
+}
+
+// stuckRule ignores ctx and only completes once released, like a regex which does not check ctx
+type stuckRule struct {
+	metadata rules.RuleMetadata
+	started  atomic.Int32
+	release  chan struct{}
+}
+
+func (r *stuckRule) GetMetadata() *rules.RuleMetadata {
+	return &r.metadata
+}
+
+func (r *stuckRule) Run(ctx context.Context, fileToScan files.File) []rules.Occurrence {
+	r.started.Add(1)
+	<-r.release
+	return nil
+}
+
+// countingRule counts the files it is run on
+type countingRule struct {
+	fileNameRule
+	runCount int
+}
+
+func (r *countingRule) Run(ctx context.Context, fileToScan files.File) []rules.Occurrence {
+	r.runCount++
+	return r.fileNameRule.Run(ctx, fileToScan)
+}
+
+func writeFile(t *testing.T, path string, content string) {
+	if err := os.WriteFile(path, []byte(content), 0600); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestNewScanner_WhenCreatedConcurrently_RunsEachStandardRuleOnce(t *testing.T) {
+	//Given
+	scanners := make([]*Scanner, 8)
+	var waitGroup sync.WaitGroup
+
+	//When
+	for index := range scanners {
+		waitGroup.Add(1)
+		go func() {
+			defer waitGroup.Done()
+			scanner, err := NewScanner(nil, ScanOptions{})
+			if err != nil {
+				t.Errorf("Should not return any error! %v", err)
+			}
+			scanners[index] = scanner
+		}()
+	}
+	waitGroup.Wait()
+
+	//Then
+	for _, scanner := range scanners {
+		ruleIds := map[rules.RuleID]bool{}
+		for _, rule := range scanner.Rules() {
+			ruleId := (*rule).GetMetadata().ID
+			if ruleIds[ruleId] {
+				t.Errorf("Rule %s should only be run once", ruleId)
+			}
+			ruleIds[ruleId] = true
+		}
+		if len(ruleIds) == 0 {
+			t.Errorf("Expected all standard rules to be run")
+		}
+	}
+}
+
+func TestNewScanner_WhenScannersHaveDifferentOverrides_RulesAreIndependent(t *testing.T) {
+	//Given
+	overridingConfig := &config.Config{
+		RuleOverrides: map[string]rules.RuleMetadataOverride{"ApexClassNoSharing": {Severity: "Low"}},
+	}
+	scanOptions := ScanOptions{Rules: []rules.RuleID{"ApexClassNoSharing"}}
+
+	//When
+	overridingScanner, err := NewScanner(overridingConfig, scanOptions)
+	if err != nil {
+		t.Fatalf("Should not return any error! %v", err)
+	}
+	defaultScanner, err := NewScanner(nil, scanOptions)
+	if err != nil {
+		t.Fatalf("Should not return any error! %v", err)
+	}
+
+	//Then
+	if len(overridingScanner.Rules()) != 1 || len(defaultScanner.Rules()) != 1 {
+		t.Fatalf("Expected a single rule per scanner. Actual: %d, %d", len(overridingScanner.Rules()), len(defaultScanner.Rules()))
+	}
+	if severity := (*overridingScanner.Rules()[0]).GetMetadata().Severity; severity != rules.SeverityLow {
+		t.Errorf("Severity should be overridden. Actual: %v", severity)
+	}
+	if severity := (*defaultScanner.Rules()[0]).GetMetadata().Severity; severity != rules.SeverityMedium {
+		t.Errorf("Severity override of another scanner should not apply. Actual: %v", severity)
+	}
+}
+
+func TestScan_WhenFolderHasExcludedFiles_ReturnsFindingsOfOtherFiles(t *testing.T) {
+	//Given
+	rootPath := t.TempDir()
+	writeFile(t, filepath.Join(rootPath, "Foo.cls"), "public class Foo {\n}\n")
+	writeFile(t, filepath.Join(rootPath, "Excluded.cls"), "public class Excluded {\n}\n")
+	cfg := &config.Config{DontGitIgnore: true, DontForceIgnore: true, ExcludeFilesAndFolders: []string{"Excluded.cls"}}
+	scanner, err := NewScanner(cfg, ScanOptions{Rules: []rules.RuleID{"ApexClassNoSharing"}, Jobs: 1})
+	if err != nil {
+		t.Fatalf("Should not return any error! %v", err)
+	}
+
+	//When
+	actualResult, err := scanner.Scan(context.Background(), []string{rootPath})
+
+	//Then
+	if err != nil {
+		t.Fatalf("Should not return any error! %v", err)
+	}
+	if actualResult.Count != 1 || actualResult.Results[0].Occurrence.FileName != filepath.Join(rootPath, "Foo.cls") {
+		t.Errorf("Expected a single finding in Foo.cls. Actual: %+v", actualResult)
+	}
+}
+
+func TestScanFiles_WhenFileIsCached_RunsRulesOnlyOnChangedFiles(t *testing.T) {
+	//Given
+	rootPath := t.TempDir()
+	unchangedPath, changedPath := filepath.Join(rootPath, "Unchanged.cls"), filepath.Join(rootPath, "Changed.cls")
+	writeFile(t, unchangedPath, "public class Unchanged {\n}\n")
+	writeFile(t, changedPath, "public class Changed {\n}\n")
+	countingRuleInstance := &countingRule{fileNameRule: fileNameRule{metadata: rules.RuleMetadata{ID: "countingRule"}}}
+	rule := rules.Rule(countingRuleInstance)
+	scanOptions := ScanOptions{Jobs: 1, Cache: cache.Open(filepath.Join(rootPath, ".asist-cache"), "key")}
+	if _, err := NewScannerWithRules(nil, []*rules.Rule{&rule}, scanOptions).ScanFiles(context.Background(), []string{unchangedPath, changedPath}); err != nil {
+		t.Fatalf("Should not return any error! %v", err)
+	}
+	writeFile(t, changedPath, "public class Changed {\n\n}\n")
+	countingRuleInstance.runCount = 0
+
+	//When
+	actualResult, err := NewScannerWithRules(nil, []*rules.Rule{&rule}, scanOptions).ScanFiles(context.Background(), []string{unchangedPath, changedPath})
+
+	//Then
+	if err != nil {
+		t.Fatalf("Should not return any error! %v", err)
+	}
+	if countingRuleInstance.runCount != 1 {
+		t.Errorf("Expected the rule to only run on the changed file. Actual runs: %d", countingRuleInstance.runCount)
+	}
+	if actualResult.Count != 2 || actualResult.Results[0].Occurrence.LineNumber != 2 || actualResult.Results[1].Occurrence.LineNumber != 3 {
+		t.Errorf("Expected the cached finding of the unchanged file and the new finding of the changed file. Actual: %+v", actualResult)
+	}
+}
+
+func TestScanFiles_WhenBaselineScanIsCached_KeepsFalsePositives(t *testing.T) {
+	//Given
+	rootPath := t.TempDir()
+	path := filepath.Join(rootPath, "Foo.cls")
+	writeFile(t, path, "// asist-ignore-begin:[ApexClassNoSharing]\npublic class Foo {\n}\n// asist-ignore-end\n")
+	cacheDir := filepath.Join(rootPath, ".asist-cache")
+	scanOptions := ScanOptions{Rules: []rules.RuleID{"ApexClassNoSharing"}, BaselineScan: true, Jobs: 1, Cache: cache.Open(cacheDir, "key")}
+	scanner, err := NewScanner(nil, scanOptions)
+	if err != nil {
+		t.Fatalf("Should not return any error! %v", err)
+	}
+	if _, err := scanner.ScanFiles(context.Background(), []string{path}); err != nil {
+		t.Fatalf("Should not return any error! %v", err)
+	}
+	if err := scanOptions.Cache.Write(); err != nil {
+		t.Fatalf("Should not return error while writing the cache: %v", err)
+	}
+	scanOptions.Cache = cache.Open(cacheDir, "key")
+	cachedScanner, _ := NewScanner(nil, scanOptions)
+
+	//When
+	actualResult, err := cachedScanner.ScanFiles(context.Background(), []string{path})
+
+	//Then
+	if err != nil {
+		t.Fatalf("Should not return any error! %v", err)
+	}
+	if actualResult.Count != 1 || !actualResult.Results[0].Occurrence.IsFalsePositive {
+		t.Errorf("Expected the cached finding to stay a false positive. Actual: %+v", actualResult.Results)
+	}
+}
+
+func TestScanFiles_WhenContextIsCanceled_ReturnsContextError(t *testing.T) {
+	//Given
+	rule := rules.Rule(&fileNameRule{metadata: rules.RuleMetadata{ID: "fileNameRule"}})
+	scanner := NewScannerWithRules(nil, []*rules.Rule{&rule}, ScanOptions{})
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	//When
+	_, err := scanner.ScanFiles(ctx, []string{"a.cls", "b.cls"})
+
+	//Then
+	if !errors.Is(err, context.Canceled) {
+		t.Errorf("Expected context canceled error. Actual: %v", err)
+	}
+}
+
+func TestScanFile_WhenContentHasVirtualFileName_RunsRulesMatchingTheVirtualFileName(t *testing.T) {
+	//Given
+	classRule := rules.Rule(&fileNameRule{metadata: rules.RuleMetadata{ID: "classRule", IncludePattern: "\\.cls$", ExcludePattern: "Test\\.cls$"}})
+	pageRule := rules.Rule(&fileNameRule{metadata: rules.RuleMetadata{ID: "pageRule", IncludePattern: "\\.page$"}})
+	scanner := NewScannerWithRules(nil, []*rules.Rule{&classRule, &pageRule}, ScanOptions{})
+	virtualFileName := "force-app/main/default/classes/Foo.cls"
+	fileMaster, _ := files.Parse(virtualFileName, strings.NewReader("public class Foo {\n}\n"))
+
+	//When
+	actualResult := scanner.ScanFile(context.Background(), fileMaster).Results
+
+	//Then
+	if len(actualResult) != 1 || actualResult[0].ID != "classRule" {
+		t.Fatalf("Only the rule matching the virtual file name should run. Actual: %+v", actualResult)
+	}
+	if actualResult[0].Occurrence.FileName != virtualFileName || actualResult[0].Occurrence.LineNumber != 2 {
+		t.Errorf("Finding should be reported on the virtual file. Actual: %+v", actualResult[0].Occurrence)
+	}
+}
+
+func TestScanFile_WhenRuleExceedsFileTimeout_ReturnsTimeoutDiagnostic(t *testing.T) {
+	//Given
+	classRule := rules.Rule(&fileNameRule{metadata: rules.RuleMetadata{ID: "classRule"}})
+	slowRule := rules.Rule(&blockingRule{metadata: rules.RuleMetadata{ID: "slowRule"}})
+	scanner := NewScannerWithRules(nil, []*rules.Rule{&classRule, &slowRule}, ScanOptions{FileTimeout: 10 * time.Millisecond})
+	fileMaster, _ := files.Parse("Foo.cls", strings.NewReader("public class Foo {\n}\n"))
+
+	//When
+	actualResult := scanner.ScanFile(context.Background(), fileMaster)
+
+	//Then
+	if actualResult.Count != 1 || actualResult.Results[0].ID != "classRule" {
+		t.Errorf("Findings of the rules run before the timeout should be kept. Actual: %+v", actualResult.Results)
+	}
+	if len(actualResult.Diagnostics) != 1 {
+		t.Fatalf("Expected a single diagnostic. Actual: %+v", actualResult.Diagnostics)
+	}
+	diagnostic := actualResult.Diagnostics[0]
+	if diagnostic.FileName != "Foo.cls" || diagnostic.RuleID != "slowRule" || diagnostic.Status != finding.DiagnosticStatusSkipped || diagnostic.Reason != finding.DiagnosticReasonTimeout {
+		t.Errorf("Expected a skipped timeout diagnostic for slowRule on Foo.cls. Actual: %+v", diagnostic)
+	}
+}
+
+func TestScanFile_WhenAbandonedRulesReachCap_DoesNotStartMoreRules(t *testing.T) {
+	//Given
+	rule := &stuckRule{metadata: rules.RuleMetadata{ID: "stuckRule"}, release: make(chan struct{})}
+	defer close(rule.release)
+	stuck := rules.Rule(rule)
+	scanner := NewScannerWithRules(nil, []*rules.Rule{&stuck}, ScanOptions{Jobs: 1, FileTimeout: 10 * time.Millisecond})
+	fileMaster, _ := files.Parse("Foo.cls", strings.NewReader("public class Foo {\n}\n"))
+
+	//When
+	var actualResult *finding.Output
+	for range 3 {
+		actualResult = scanner.ScanFile(context.Background(), fileMaster)
+	}
+
+	//Then
+	if started := rule.started.Load(); started != 2 {
+		t.Errorf("Abandoned rules should be capped to twice the jobs. Actual started: %d", started)
+	}
+	if len(actualResult.Diagnostics) != 1 || actualResult.Diagnostics[0].Reason != finding.DiagnosticReasonTimeout {
+		t.Errorf("Expected a timeout diagnostic once the cap is reached. Actual: %+v", actualResult.Diagnostics)
+	}
+}
+
+func TestScanFile_WhenRulesCompleteBeforeFileTimeout_ReturnsNoDiagnostic(t *testing.T) {
+	//Given
+	classRule := rules.Rule(&fileNameRule{metadata: rules.RuleMetadata{ID: "classRule"}})
+	scanner := NewScannerWithRules(nil, []*rules.Rule{&classRule}, ScanOptions{FileTimeout: time.Minute})
+	fileMaster, _ := files.Parse("Foo.cls", strings.NewReader("public class Foo {\n}\n"))
+
+	//When
+	actualResult := scanner.ScanFile(context.Background(), fileMaster)
+
+	//Then
+	if actualResult.Count != 1 || len(actualResult.Diagnostics) != 0 {
+		t.Errorf("Expected a single finding and no diagnostic. Actual: %+v", actualResult)
+	}
+}
+
+func TestScanFile_WhenLongLineIsChunked_ReturnsColumnRangeInSourceLine(t *testing.T) {
+	//Given
+	scanner, err := NewScanner(nil, ScanOptions{Rules: []rules.RuleID{"XSSLocationSearch"}, ParseOptions: files.ParseOptions{MaxLineLength: 64, ChunkLongLines: true}})
+	if err != nil {
+		t.Fatalf("Should not return any error! %v", err)
+	}
+	minifiedContent := strings.Repeat("x", 100) + "location.search" + strings.Repeat("y", 100)
+	fileMaster, _ := files.ParseWithOptions("bundle.js", strings.NewReader(minifiedContent), scanner.ParseOptions())
+
+	//When
+	actualResult := scanner.ScanFile(context.Background(), fileMaster)
+
+	//Then
+	if actualResult.Count != 1 {
+		t.Fatalf("Expected a single finding. Actual: %+v", actualResult.Results)
+	}
+	if columnRange := actualResult.Results[0].Occurrence.ColumnRange; !reflect.DeepEqual(columnRange, []int{100, 115}) {
+		t.Errorf("Column range should be relative to the source line. Actual: %v", columnRange)
+	}
+}
+
+func TestScanFile_WhenChunksAreIdentical_ReturnsColumnRangeOfEachChunk(t *testing.T) {
+	//Given
+	scanner, err := NewScanner(nil, ScanOptions{Rules: []rules.RuleID{"XSSLocationSearch"}, ParseOptions: files.ParseOptions{MaxLineLength: 20, ChunkLongLines: true}})
+	if err != nil {
+		t.Fatalf("Should not return any error! %v", err)
+	}
+	chunk := "location.search;xxxx"
+	fileMaster, _ := files.ParseWithOptions("bundle.js", strings.NewReader(chunk+chunk), scanner.ParseOptions())
+
+	//When
+	actualResult := scanner.ScanFile(context.Background(), fileMaster)
+
+	//Then
+	if actualResult.Count != 2 {
+		t.Fatalf("Expected a finding per chunk. Actual: %+v", actualResult.Results)
+	}
+	if columnRange := actualResult.Results[1].Occurrence.ColumnRange; !reflect.DeepEqual(columnRange, []int{20, 35}) {
+		t.Errorf("Column range of the second chunk should be relative to the source line. Actual: %v", columnRange)
+	}
+}
+
+func TestScanFile_WhenLinesAreTruncated_ReturnsTruncatedDiagnostic(t *testing.T) {
+	//Given
+	classRule := rules.Rule(&fileNameRule{metadata: rules.RuleMetadata{ID: "classRule"}})
+	scanner := NewScannerWithRules(nil, []*rules.Rule{&classRule}, ScanOptions{ParseOptions: files.ParseOptions{MaxLineLength: 10}})
+	fileMaster, _ := files.ParseWithOptions("bundle.js", strings.NewReader("short\n"+strings.Repeat("x", 20)+"\n"), scanner.ParseOptions())
+
+	//When
+	actualResult := scanner.ScanFile(context.Background(), fileMaster)
+
+	//Then
+	if len(actualResult.Diagnostics) != 1 {
+		t.Fatalf("Expected a single diagnostic. Actual: %+v", actualResult.Diagnostics)
+	}
+	diagnostic := actualResult.Diagnostics[0]
+	if diagnostic.FileName != "bundle.js" || diagnostic.Status != finding.DiagnosticStatusTruncated || diagnostic.Reason != finding.DiagnosticReasonLineTooLong {
+		t.Errorf("Expected a truncated diagnostic for bundle.js. Actual: %+v", diagnostic)
+	}
+}
@@ -1,15 +1,28 @@
 package files
 
+import (
+	"sort"
+	"strings"
+)
+
 type Line struct {
 	LineNumber      int
 	Text            string
 	IsCommentedLine bool
+	// ColumnOffset is the position of Text in the source line, non zero for the chunks of a long line after the first one
+	ColumnOffset int
 }
 
 type File struct {
 	Lines           []Line
 	FileName        string
 	IgnoresSelected []IgnoreSelected
+	// IncludeFalsePositives is set to also report the occurrences marked as false positive, e.g. for baseline scans
+	IncludeFalsePositives bool
+	// TruncatedLines are the numbers of the lines longer than the maximum line length which were truncated
+	TruncatedLines []int
+	// ReadError is the error which stopped reading the content, only the lines read before it are scanned
+	ReadError error
 }
 
 type IgnoreSelected struct {
@@ -31,3 +44,41 @@ func (f *File) IsLineMarkedFalsePositive(ruleID string, lineNumber int) bool {
 	}
 	return false
 }
+
+/**
+ * GetSurroundingLines - method used to get the text of up to window lines before and after a line number
+ */
+func (f *File) GetSurroundingLines(lineNumber int, window int) []string {
+	var surroundingLines []string
+	// Lines are sorted by line number
+	firstIndex := sort.Search(len(f.Lines), func(index int) bool {
+		return f.Lines[index].LineNumber >= lineNumber-window
+	})
+	for _, line := range f.Lines[firstIndex:] {
+		if line.LineNumber > lineNumber+window {
+			break
+		}
+		if line.LineNumber != lineNumber {
+			surroundingLines = append(surroundingLines, line.Text)
+		}
+	}
+	return surroundingLines
+}
+
+/**
+ * GetSourceLine - method used to get the text of a source line, joining the chunks of a long line
+ */
+func (f *File) GetSourceLine(lineNumber int) string {
+	// Lines are sorted by line number, and the chunks of a line by column offset
+	firstIndex := sort.Search(len(f.Lines), func(index int) bool {
+		return f.Lines[index].LineNumber >= lineNumber
+	})
+	var sourceLine strings.Builder
+	for _, line := range f.Lines[firstIndex:] {
+		if line.LineNumber != lineNumber {
+			break
+		}
+		sourceLine.WriteString(line.Text)
+	}
+	return sourceLine.String()
+}
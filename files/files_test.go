@@ -1,9 +1,11 @@
 package files
 
 import (
+	"errors"
 	"os"
 	"path/filepath"
 	"reflect"
+	"strings"
 	"testing"
 )
 
@@ -114,3 +116,108 @@ func initializeData() {
 		IgnoresSelected: ignoreSelected,
 	}
 }
+
+func TestGetSurroundingLines_WhenLineInMiddle_ReturnsLinesAround(t *testing.T) {
+	//Given
+	file := File{Lines: []Line{{LineNumber: 1, Text: "a"}, {LineNumber: 2, Text: "b"}, {LineNumber: 3, Text: "c"}, {LineNumber: 4, Text: "d"}}}
+
+	//When
+	actualResult := file.GetSurroundingLines(2, 1)
+
+	//Then
+	if !reflect.DeepEqual(actualResult, []string{"a", "c"}) {
+		t.Errorf("%s Actual: %+v, Expected: %+v", "Surrounding lines mismatched!", actualResult, []string{"a", "c"})
+	}
+}
+
+func TestGetRepoRelativePath_WhenFileInRepository_ReturnsPathFromRepositoryRoot(t *testing.T) {
+	//Given
+	rootPath := t.TempDir()
+	os.MkdirAll(filepath.Join(rootPath, ".git"), 0750)
+	os.MkdirAll(filepath.Join(rootPath, "force-app", "classes"), 0750)
+
+	//When
+	actualResult := GetRepoRelativePath(filepath.Join(rootPath, "force-app", "classes", "A.cls"))
+
+	//Then
+	if actualResult != "force-app/classes/A.cls" {
+		t.Errorf("%s Actual: %+v, Expected: %+v", "Repository relative path mismatched!", actualResult, "force-app/classes/A.cls")
+	}
+}
+
+type failingReader struct {
+	content string
+}
+
+func (r *failingReader) Read(p []byte) (int, error) {
+	if r.content == "" {
+		return 0, errors.New("connection reset")
+	}
+	n := copy(p, r.content)
+	r.content = r.content[n:]
+	return n, nil
+}
+
+func TestParse_WhenLineIsLongerThanScannerBuffer_ReturnsAllLines(t *testing.T) {
+	//Given
+	longLine := strings.Repeat("a", 200*1024)
+	content := "first\r\n" + longLine + "\nlast"
+
+	//When
+	actualResult, err := Parse("bundle.min.js", strings.NewReader(content))
+
+	//Then
+	if err != nil || actualResult.ReadError != nil {
+		t.Fatalf("Should not return any error! %v, %v", err, actualResult.ReadError)
+	}
+	if len(actualResult.Lines) != 3 || actualResult.Lines[0].Text != "first" || actualResult.Lines[1].Text != longLine || actualResult.Lines[2].Text != "last" {
+		t.Errorf("All lines should be read entirely. Actual line count: %d", len(actualResult.Lines))
+	}
+}
+
+func TestParseWithOptions_WhenLineIsTooLong_TruncatesLine(t *testing.T) {
+	//When
+	actualResult, _ := ParseWithOptions("bundle.min.js", strings.NewReader("short\n0123456789\n"), ParseOptions{MaxLineLength: 6})
+
+	//Then
+	expectedLines := []Line{{LineNumber: 1, Text: "short"}, {LineNumber: 2, Text: "012345"}}
+	if !reflect.DeepEqual(actualResult.Lines, expectedLines) {
+		t.Errorf("%s Actual: %+v, Expected: %+v", "Lines mismatched!", actualResult.Lines, expectedLines)
+	}
+	if !reflect.DeepEqual(actualResult.TruncatedLines, []int{2}) {
+		t.Errorf("%s Actual: %+v, Expected: %+v", "Truncated lines mismatched!", actualResult.TruncatedLines, []int{2})
+	}
+}
+
+func TestParseWithOptions_WhenChunkingLongLines_SplitsLineWithoutBreakingCharacters(t *testing.T) {
+	//When
+	actualResult, _ := ParseWithOptions("bundle.min.js", strings.NewReader("abcdé€fg"), ParseOptions{MaxLineLength: 5, ChunkLongLines: true})
+
+	//Then
+	expectedLines := []Line{{LineNumber: 1, Text: "abcd"}, {LineNumber: 1, Text: "é€", ColumnOffset: 4}, {LineNumber: 1, Text: "fg", ColumnOffset: 9}}
+	if !reflect.DeepEqual(actualResult.Lines, expectedLines) {
+		t.Errorf("%s Actual: %+v, Expected: %+v", "Chunks mismatched!", actualResult.Lines, expectedLines)
+	}
+	if len(actualResult.TruncatedLines) != 0 {
+		t.Errorf("Chunked lines should not be reported as truncated. Actual: %+v", actualResult.TruncatedLines)
+	}
+	if sourceLine := actualResult.GetSourceLine(1); sourceLine != "abcdé€fg" {
+		t.Errorf("%s Actual: %+v, Expected: %+v", "Source line mismatched!", sourceLine, "abcdé€fg")
+	}
+}
+
+func TestParse_WhenReadFails_KeepsLinesReadBeforeError(t *testing.T) {
+	//When
+	actualResult, err := Parse("Foo.cls", &failingReader{content: "public class Foo {\n}"})
+
+	//Then
+	if err != nil {
+		t.Fatalf("Should not return any error! %v", err)
+	}
+	if actualResult.ReadError == nil || actualResult.ReadError.Error() != "connection reset" {
+		t.Errorf("Read error should be kept. Actual: %v", actualResult.ReadError)
+	}
+	if len(actualResult.Lines) != 2 {
+		t.Errorf("Lines read before the error should be kept. Actual: %+v", actualResult.Lines)
+	}
+}
@@ -0,0 +1,158 @@
+package gitdiff
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/go-git/go-git/v5"
+	"github.com/go-git/go-git/v5/plumbing"
+	"github.com/go-git/go-git/v5/utils/diff"
+	"github.com/sergi/go-diff/diffmatchpatch"
+
+	"github.com/certinia/asist/errorhandler"
+	"github.com/certinia/asist/message"
+)
+
+// BlameLine is the commit which last modified a line
+type BlameLine struct {
+	Hash   string
+	Author string
+	Email  string
+	Date   time.Time
+}
+
+// Blamer finds the commits which last modified the lines of the files of a repository, each file is blamed once.
+// Different files are blamed in parallel.
+type Blamer struct {
+	worktreeRoot string
+	headHash     plumbing.Hash
+	// mutex only guards blamedFiles, the files are blamed outside of it
+	mutex       sync.Mutex
+	blamedFiles map[string]*blamedFile
+}
+
+// blamedFile is the blame of a file, computed once by the first goroutine asking for one of its lines
+type blamedFile struct {
+	once  sync.Once
+	lines []*BlameLine
+	err   error
+}
+
+/**
+ * NewBlamer - method used to create a blamer for the repository containing the root path.
+ *	Before the first commit, no line has a commit.
+ */
+func NewBlamer(rootPath string) (*Blamer, error) {
+	repository, err := git.PlainOpenWithOptions(rootPath, &git.PlainOpenOptions{DetectDotGit: true})
+	if err != nil {
+		return nil, errorhandler.NewUserError(message.GetGitRepositoryError(rootPath, err))
+	}
+	worktree, err := repository.Worktree()
+	if err != nil {
+		return nil, errorhandler.NewUserError(message.GetGitRepositoryError(rootPath, err))
+	}
+	blamer := &Blamer{worktreeRoot: worktree.Filesystem.Root(), blamedFiles: map[string]*blamedFile{}}
+	head, err := repository.Head()
+	if errors.Is(err, plumbing.ErrReferenceNotFound) {
+		return blamer, nil
+	}
+	if err != nil {
+		return nil, errorhandler.NewUserError(message.GetGitRevisionError(string(plumbing.HEAD), err))
+	}
+	if _, err = repository.CommitObject(head.Hash()); err != nil {
+		return nil, errorhandler.NewUserError(message.GetGitRevisionError(string(plumbing.HEAD), err))
+	}
+	blamer.headHash = head.Hash()
+	return blamer, nil
+}
+
+/**
+ * GetLine - method used to get the commit which last modified a 1-based line of the working tree version of a file.
+ *	Returns nil for the lines which are not committed yet and for the files outside of the repository.
+ */
+func (b *Blamer) GetLine(path string, lineNumber int) (*BlameLine, error) {
+	b.mutex.Lock()
+	file, isBlamed := b.blamedFiles[path]
+	if !isBlamed {
+		file = &blamedFile{}
+		b.blamedFiles[path] = file
+	}
+	b.mutex.Unlock()
+
+	file.once.Do(func() {
+		file.lines, file.err = b.blameFile(path)
+	})
+	if file.err != nil {
+		return nil, errorhandler.NewInternalError(message.GetBlameError(path, file.err))
+	}
+	if lineNumber < 1 || lineNumber > len(file.lines) {
+		return nil, nil
+	}
+	return file.lines[lineNumber-1], nil
+}
+
+/**
+ * blameFile - method used to blame the HEAD version of a file and map its lines to the lines of the working tree version,
+ *	the lines added or modified in the working tree have no commit.
+ *	The repository is opened for each file, as the storage of go-git cannot be read by several goroutines at once.
+ */
+func (b *Blamer) blameFile(path string) ([]*BlameLine, error) {
+	relPath, err := filepath.Rel(b.worktreeRoot, path)
+	if b.headHash.IsZero() || err != nil || strings.HasPrefix(relPath, "..") {
+		return nil, nil
+	}
+	relPath = filepath.ToSlash(relPath)
+	repository, err := git.PlainOpenWithOptions(b.worktreeRoot, &git.PlainOpenOptions{DetectDotGit: true})
+	if err != nil {
+		return nil, err
+	}
+	headCommit, err := repository.CommitObject(b.headHash)
+	if err != nil {
+		return nil, err
+	}
+	headTree, err := headCommit.Tree()
+	if err != nil {
+		return nil, err
+	}
+	headContent, err := getFileContentAtTree(headTree, relPath)
+	if err != nil || headContent == "" {
+		return nil, err
+	}
+	currentContent, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	blameResult, err := git.Blame(headCommit, relPath)
+	if err != nil {
+		return nil, err
+	}
+
+	blameLines := []*BlameLine{}
+	headLine := 0
+	for _, lineDiff := range diff.Do(headContent, string(currentContent)) {
+		lineCount := countLines(lineDiff.Text)
+		switch lineDiff.Type {
+		case diffmatchpatch.DiffEqual:
+			for index := headLine; index < headLine+lineCount; index++ {
+				if index >= len(blameResult.Lines) {
+					blameLines = append(blameLines, nil)
+					continue
+				}
+				line := blameResult.Lines[index]
+				blameLines = append(blameLines, &BlameLine{Hash: line.Hash.String(), Author: line.AuthorName, Email: line.Author, Date: line.Date})
+			}
+			headLine += lineCount
+		case diffmatchpatch.DiffInsert:
+			for range lineCount {
+				blameLines = append(blameLines, nil)
+			}
+		case diffmatchpatch.DiffDelete:
+			headLine += lineCount
+		}
+	}
+	return blameLines, nil
+}
@@ -0,0 +1,94 @@
+package gitdiff
+
+import (
+	"path/filepath"
+	"sync"
+	"testing"
+)
+
+func TestBlamerGetLine_WhenWorkingTreeIsModified_ReturnsCommitOfCommittedLinesOnly(t *testing.T) {
+	//Given
+	rootPath := createRepository(t, map[string]string{"a.cls": "line1\nline2\n"})
+	commitFile(t, rootPath, "a.cls", "line1\nadded\nline2\n", "second")
+	path := filepath.Join(rootPath, "a.cls")
+	writeFile(t, path, "uncommitted\nline1\nadded\nline2\n")
+	blamer, err := NewBlamer(rootPath)
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	//When
+	uncommittedLine, _ := blamer.GetLine(path, 1)
+	firstCommitLine, _ := blamer.GetLine(path, 2)
+	secondCommitLine, _ := blamer.GetLine(path, 3)
+	shiftedLine, err := blamer.GetLine(path, 4)
+
+	//Then
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if uncommittedLine != nil {
+		t.Errorf("Expected no commit for the uncommitted line. Actual: %+v", uncommittedLine)
+	}
+	if firstCommitLine == nil || secondCommitLine == nil || shiftedLine == nil {
+		t.Fatalf("Expected commits for the committed lines. Actual: %+v %+v %+v", firstCommitLine, secondCommitLine, shiftedLine)
+	}
+	if firstCommitLine.Hash == secondCommitLine.Hash || shiftedLine.Hash != firstCommitLine.Hash {
+		t.Errorf("Expected the lines of the first commit to be told apart from the added line. Actual: %s %s %s", firstCommitLine.Hash, secondCommitLine.Hash, shiftedLine.Hash)
+	}
+	if secondCommitLine.Author != "author" || secondCommitLine.Email != "author@example.com" {
+		t.Errorf("Author mismatched. Actual: %+v", secondCommitLine)
+	}
+}
+
+func TestBlamerGetLine_WhenFileIsNotCommitted_ReturnsNoCommit(t *testing.T) {
+	//Given
+	rootPath := createRepository(t, map[string]string{"a.cls": "line1\n"})
+	path := filepath.Join(rootPath, "new.cls")
+	writeFile(t, path, "new\n")
+	blamer, _ := NewBlamer(rootPath)
+
+	//When
+	actualResult, err := blamer.GetLine(path, 1)
+
+	//Then
+	if err != nil || actualResult != nil {
+		t.Errorf("Expected no commit and no error. Actual: %+v, %v", actualResult, err)
+	}
+}
+
+func TestBlamerGetLine_WhenFilesAreBlamedConcurrently_ReturnsCommitOfEachFile(t *testing.T) {
+	//Given
+	fileContents := map[string]string{"a.cls": "a\n", "b.cls": "b\n", "c.cls": "c\n", "d.cls": "d\n"}
+	rootPath := createRepository(t, fileContents)
+	blamer, err := NewBlamer(rootPath)
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	//When
+	var waitGroup sync.WaitGroup
+	blameLines := make(chan *BlameLine, 2*len(fileContents))
+	for range 2 {
+		for fileName := range fileContents {
+			waitGroup.Add(1)
+			go func() {
+				defer waitGroup.Done()
+				blameLine, err := blamer.GetLine(filepath.Join(rootPath, fileName), 1)
+				if err != nil {
+					t.Errorf("Unexpected error: %v", err)
+				}
+				blameLines <- blameLine
+			}()
+		}
+	}
+	waitGroup.Wait()
+	close(blameLines)
+
+	//Then
+	for blameLine := range blameLines {
+		if blameLine == nil || blameLine.Author != "test" {
+			t.Errorf("Expected the initial commit for each file. Actual: %+v", blameLine)
+		}
+	}
+}
@@ -0,0 +1,147 @@
+package gitdiff
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/go-git/go-git/v5"
+	"github.com/go-git/go-git/v5/plumbing"
+	"github.com/go-git/go-git/v5/plumbing/object"
+	"github.com/go-git/go-git/v5/utils/diff"
+	"github.com/sergi/go-diff/diffmatchpatch"
+
+	"github.com/certinia/asist/errorhandler"
+	"github.com/certinia/asist/message"
+)
+
+// LineRange is an inclusive range of 1-based line numbers in the working tree version of a file
+type LineRange struct {
+	Start int
+	End   int
+}
+
+// ChangedLines maps the absolute path of every changed file to the line ranges added or modified in it
+type ChangedLines map[string][]LineRange
+
+/**
+ * GetChangedLines - method used to compute the files and lines of the given paths which were added or
+ *	modified in the working tree since the git ref. Files that did not exist at the ref are changed entirely.
+ */
+func GetChangedLines(rootPath string, ref string, paths []string) (ChangedLines, error) {
+	repository, err := git.PlainOpenWithOptions(rootPath, &git.PlainOpenOptions{DetectDotGit: true})
+	if err != nil {
+		return nil, errorhandler.NewUserError(message.GetGitRepositoryError(rootPath, err))
+	}
+	worktree, err := repository.Worktree()
+	if err != nil {
+		return nil, errorhandler.NewUserError(message.GetGitRepositoryError(rootPath, err))
+	}
+	tree, err := getTreeForRef(repository, ref)
+	if err != nil {
+		return nil, errorhandler.NewUserError(message.GetGitRevisionError(ref, err))
+	}
+
+	worktreeRoot := worktree.Filesystem.Root()
+	changedLines := ChangedLines{}
+	for _, path := range paths {
+		relPath, err := filepath.Rel(worktreeRoot, path)
+		if err != nil || strings.HasPrefix(relPath, "..") {
+			continue
+		}
+		currentContent, err := os.ReadFile(path)
+		if err != nil {
+			return nil, errorhandler.NewInternalError(message.GetFileReadError(path, err))
+		}
+		previousContent, err := getFileContentAtTree(tree, filepath.ToSlash(relPath))
+		if err != nil {
+			return nil, errorhandler.NewInternalError(message.GetFileReadError(path, err))
+		}
+		if previousContent == string(currentContent) {
+			continue
+		}
+		if lineRanges := getAddedLineRanges(previousContent, string(currentContent)); len(lineRanges) > 0 {
+			changedLines[path] = lineRanges
+		}
+	}
+	return changedLines, nil
+}
+
+/**
+ * FilterPaths - method used to filter out the paths which have no changed lines
+ */
+func (c ChangedLines) FilterPaths(paths []string) []string {
+	var filteredPaths []string
+	for _, path := range paths {
+		if _, isChanged := c[path]; isChanged {
+			filteredPaths = append(filteredPaths, path)
+		}
+	}
+	return filteredPaths
+}
+
+/**
+ * Contains - method used to check a line of a file was added or modified
+ */
+func (c ChangedLines) Contains(path string, lineNumber int) bool {
+	for _, lineRange := range c[path] {
+		if lineRange.Start <= lineNumber && lineNumber <= lineRange.End {
+			return true
+		}
+	}
+	return false
+}
+
+func getTreeForRef(repository *git.Repository, ref string) (*object.Tree, error) {
+	hash, err := repository.ResolveRevision(plumbing.Revision(ref))
+	if err != nil {
+		return nil, err
+	}
+	commit, err := repository.CommitObject(*hash)
+	if err != nil {
+		return nil, err
+	}
+	return commit.Tree()
+}
+
+/**
+ * getFileContentAtTree - method used to read the content of a file at a tree, files missing in the tree are empty
+ */
+func getFileContentAtTree(tree *object.Tree, relPath string) (string, error) {
+	file, err := tree.File(relPath)
+	if errors.Is(err, object.ErrFileNotFound) {
+		return "", nil
+	}
+	if err != nil {
+		return "", err
+	}
+	return file.Contents()
+}
+
+/**
+ * getAddedLineRanges - method used to compute the line ranges of the current content which are not in the previous content
+ */
+func getAddedLineRanges(previousContent string, currentContent string) []LineRange {
+	var lineRanges []LineRange
+	currentLine := 0
+	for _, lineDiff := range diff.Do(previousContent, currentContent) {
+		lineCount := countLines(lineDiff.Text)
+		switch lineDiff.Type {
+		case diffmatchpatch.DiffEqual:
+			currentLine += lineCount
+		case diffmatchpatch.DiffInsert:
+			lineRanges = append(lineRanges, LineRange{Start: currentLine + 1, End: currentLine + lineCount})
+			currentLine += lineCount
+		}
+	}
+	return lineRanges
+}
+
+func countLines(text string) int {
+	lineCount := strings.Count(text, "\n")
+	if len(text) > 0 && !strings.HasSuffix(text, "\n") {
+		lineCount++
+	}
+	return lineCount
+}
@@ -0,0 +1,110 @@
+package gitdiff
+
+import (
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/go-git/go-git/v5"
+	"github.com/go-git/go-git/v5/plumbing/object"
+)
+
+/**
+ * createRepository - helper used to create a git repository with a single commit of the given files
+ */
+func createRepository(t *testing.T, fileContents map[string]string) string {
+	rootPath := t.TempDir()
+	repository, err := git.PlainInit(rootPath, false)
+	if err != nil {
+		t.Fatal(err)
+	}
+	worktree, _ := repository.Worktree()
+	for fileName, content := range fileContents {
+		writeFile(t, filepath.Join(rootPath, fileName), content)
+		if _, err := worktree.Add(fileName); err != nil {
+			t.Fatal(err)
+		}
+	}
+	_, err = worktree.Commit("initial", &git.CommitOptions{
+		Author: &object.Signature{Name: "test", Email: "test@example.com", When: time.Now()},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	return rootPath
+}
+
+func writeFile(t *testing.T, path string, content string) {
+	if err := os.WriteFile(path, []byte(content), 0600); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestGetChangedLines_WhenFilesChangedSinceRef_ReturnsAddedLineRanges(t *testing.T) {
+	//Given
+	rootPath := createRepository(t, map[string]string{
+		"unchanged.cls": "public class A {\n}\n",
+		"changed.cls":   "line1\nline2\nline3\nline4\n",
+	})
+	unchangedPath := filepath.Join(rootPath, "unchanged.cls")
+	changedPath := filepath.Join(rootPath, "changed.cls")
+	newPath := filepath.Join(rootPath, "new.cls")
+	writeFile(t, changedPath, "line1\nadded\nline2\nmodified\nline4\nappended\n")
+	writeFile(t, newPath, "new1\nnew2\n")
+
+	//When
+	actualResult, err := GetChangedLines(rootPath, "HEAD", []string{unchangedPath, changedPath, newPath})
+
+	//Then
+	if err != nil {
+		t.Fatalf("Should not return any error! %v", err)
+	}
+	expectedResult := ChangedLines{
+		changedPath: {{Start: 2, End: 2}, {Start: 4, End: 4}, {Start: 6, End: 6}},
+		newPath:     {{Start: 1, End: 2}},
+	}
+	if !reflect.DeepEqual(actualResult, expectedResult) {
+		t.Errorf("Changed lines mismatched. Actual: %+v, Expected: %+v", actualResult, expectedResult)
+	}
+}
+
+func TestGetChangedLines_WhenRefIsInvalid_ReturnsError(t *testing.T) {
+	//Given
+	rootPath := createRepository(t, map[string]string{"a.cls": "a\n"})
+
+	//When
+	_, err := GetChangedLines(rootPath, "does-not-exist", nil)
+
+	//Then
+	if err == nil {
+		t.Errorf("Expected error for invalid ref but got nil")
+	}
+}
+
+func TestGetChangedLines_WhenNotAGitRepository_ReturnsError(t *testing.T) {
+	//When
+	_, err := GetChangedLines(t.TempDir(), "HEAD", nil)
+
+	//Then
+	if err == nil {
+		t.Errorf("Expected error outside of a git repository but got nil")
+	}
+}
+
+func TestChangedLines_FilterPathsAndContains(t *testing.T) {
+	//Given
+	changedLines := ChangedLines{"/a.cls": {{Start: 3, End: 5}}}
+
+	//When
+	actualPaths := changedLines.FilterPaths([]string{"/a.cls", "/b.cls"})
+
+	//Then
+	if !reflect.DeepEqual(actualPaths, []string{"/a.cls"}) {
+		t.Errorf("Filtered paths mismatched. Actual: %v", actualPaths)
+	}
+	if changedLines.Contains("/a.cls", 2) || !changedLines.Contains("/a.cls", 3) || !changedLines.Contains("/a.cls", 5) || changedLines.Contains("/b.cls", 3) {
+		t.Errorf("Contains should only match lines within the changed ranges")
+	}
+}
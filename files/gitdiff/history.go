@@ -0,0 +1,216 @@
+package gitdiff
+
+import (
+	"context"
+	"path/filepath"
+	"time"
+
+	"github.com/go-git/go-git/v5"
+	"github.com/go-git/go-git/v5/plumbing/filemode"
+	"github.com/go-git/go-git/v5/plumbing/object"
+	"github.com/go-git/go-git/v5/plumbing/storer"
+
+	"github.com/certinia/asist/errorhandler"
+	"github.com/certinia/asist/message"
+)
+
+// CommitChanges are the files added or modified by a commit compared with its parents
+type CommitChanges struct {
+	Hash   string
+	Author string
+	Email  string
+	Date   time.Time
+	Files  []CommittedFile
+}
+
+// CommittedFile is a file added or modified by a commit, with its content after the commit
+type CommittedFile struct {
+	// Path is the absolute path of the file in the working tree
+	Path string
+	// RelPath is the slash separated path of the file relative to the root of the working tree
+	RelPath string
+	Content []byte
+	// AddedLines are the line ranges of the content which were added or modified by the commit.
+	// The lines of a merge commit are only added when they are in none of its parents, e.g. the changes made while resolving conflicts
+	AddedLines []LineRange
+}
+
+/**
+ * WalkCommits - method used to visit the changes of the commits reachable from HEAD in the repository containing the root path,
+ *	newest first. At most maxCommits commits are visited, all of them when maxCommits is zero.
+ *	Merge commits are compared with all of their parents, so only the lines they changed themselves are visited.
+ */
+func WalkCommits(ctx context.Context, rootPath string, maxCommits int, visit func(CommitChanges) error) error {
+	repository, err := git.PlainOpenWithOptions(rootPath, &git.PlainOpenOptions{DetectDotGit: true})
+	if err != nil {
+		return errorhandler.NewUserError(message.GetGitRepositoryError(rootPath, err))
+	}
+	worktree, err := repository.Worktree()
+	if err != nil {
+		return errorhandler.NewUserError(message.GetGitRepositoryError(rootPath, err))
+	}
+	head, err := repository.Head()
+	if err != nil {
+		return errorhandler.NewUserError(message.GetGitRevisionError("HEAD", err))
+	}
+	commits, err := repository.Log(&git.LogOptions{From: head.Hash(), Order: git.LogOrderCommitterTime})
+	if err != nil {
+		return errorhandler.NewInternalError(message.GetGitHistoryError(err))
+	}
+	defer commits.Close()
+
+	visitedCount := 0
+	// Errors of the visitor are returned as they are, the other errors come from reading the repository
+	var visitErr error
+	err = commits.ForEach(func(commit *object.Commit) error {
+		if maxCommits > 0 && visitedCount >= maxCommits {
+			return storer.ErrStop
+		}
+		visitedCount++
+		committedFiles, err := getCommittedFiles(ctx, commit, worktree.Filesystem.Root())
+		if err != nil {
+			return err
+		}
+		visitErr = visit(CommitChanges{
+			Hash:   commit.Hash.String(),
+			Author: commit.Author.Name,
+			Email:  commit.Author.Email,
+			Date:   commit.Author.When,
+			Files:  committedFiles,
+		})
+		if visitErr != nil {
+			return storer.ErrStop
+		}
+		return nil
+	})
+	if ctx.Err() != nil {
+		return ctx.Err()
+	}
+	if visitErr != nil {
+		return visitErr
+	}
+	if err != nil {
+		return errorhandler.NewInternalError(message.GetGitHistoryError(err))
+	}
+	return nil
+}
+
+/**
+ * getCommittedFiles - method used to get the files added or modified by a commit with their added lines.
+ *	The files of a merge commit are the files changed compared with every parent, with the lines added compared with every parent.
+ */
+func getCommittedFiles(ctx context.Context, commit *object.Commit, worktreeRoot string) ([]CommittedFile, error) {
+	tree, err := commit.Tree()
+	if err != nil {
+		return nil, err
+	}
+	// The changes of the first commit are compared with an empty tree
+	if commit.NumParents() == 0 {
+		return getChangedFiles(ctx, nil, tree, worktreeRoot)
+	}
+	var committedFiles []CommittedFile
+	for index := range commit.NumParents() {
+		parent, err := commit.Parent(index)
+		if err != nil {
+			return nil, err
+		}
+		parentTree, err := parent.Tree()
+		if err != nil {
+			return nil, err
+		}
+		changedFiles, err := getChangedFiles(ctx, parentTree, tree, worktreeRoot)
+		if err != nil {
+			return nil, err
+		}
+		if index == 0 {
+			committedFiles = changedFiles
+			continue
+		}
+		committedFiles = intersectCommittedFiles(committedFiles, changedFiles)
+	}
+	return committedFiles, nil
+}
+
+/**
+ * intersectCommittedFiles - method used to keep the files and the added lines of committedFiles which are also in changedFiles
+ */
+func intersectCommittedFiles(committedFiles []CommittedFile, changedFiles []CommittedFile) []CommittedFile {
+	changedLinesByPath := map[string][]LineRange{}
+	for _, changedFile := range changedFiles {
+		changedLinesByPath[changedFile.RelPath] = changedFile.AddedLines
+	}
+	intersectedFiles := []CommittedFile{}
+	for _, committedFile := range committedFiles {
+		committedFile.AddedLines = intersectLineRanges(committedFile.AddedLines, changedLinesByPath[committedFile.RelPath])
+		if len(committedFile.AddedLines) > 0 {
+			intersectedFiles = append(intersectedFiles, committedFile)
+		}
+	}
+	return intersectedFiles
+}
+
+/**
+ * intersectLineRanges - method used to get the lines in both sorted lists of line ranges
+ */
+func intersectLineRanges(lineRanges []LineRange, otherLineRanges []LineRange) []LineRange {
+	var intersection []LineRange
+	for i, j := 0, 0; i < len(lineRanges) && j < len(otherLineRanges); {
+		start := max(lineRanges[i].Start, otherLineRanges[j].Start)
+		end := min(lineRanges[i].End, otherLineRanges[j].End)
+		if start <= end {
+			intersection = append(intersection, LineRange{Start: start, End: end})
+		}
+		if lineRanges[i].End < otherLineRanges[j].End {
+			i++
+		} else {
+			j++
+		}
+	}
+	return intersection
+}
+
+/**
+ * getChangedFiles - method used to get the files added or modified between the parent tree and the tree with their added lines.
+ *	Renamed files only have the lines modified while renaming them, submodules and binary files are skipped.
+ */
+func getChangedFiles(ctx context.Context, parentTree *object.Tree, tree *object.Tree, worktreeRoot string) ([]CommittedFile, error) {
+	changes, err := object.DiffTreeWithOptions(ctx, parentTree, tree, object.DefaultDiffTreeOptions)
+	if err != nil {
+		return nil, err
+	}
+
+	committedFiles := []CommittedFile{}
+	for _, change := range changes {
+		if change.To.Name == "" || change.To.TreeEntry.Mode == filemode.Submodule || change.From.TreeEntry.Mode == filemode.Submodule {
+			continue
+		}
+		fromFile, toFile, err := change.Files()
+		if err != nil {
+			return nil, err
+		}
+		if isBinary, err := toFile.IsBinary(); err != nil || isBinary {
+			continue
+		}
+		previousContent := ""
+		if fromFile != nil {
+			if previousContent, err = fromFile.Contents(); err != nil {
+				return nil, err
+			}
+		}
+		currentContent, err := toFile.Contents()
+		if err != nil {
+			return nil, err
+		}
+		addedLines := getAddedLineRanges(previousContent, currentContent)
+		if len(addedLines) == 0 {
+			continue
+		}
+		committedFiles = append(committedFiles, CommittedFile{
+			Path:       filepath.Join(worktreeRoot, filepath.FromSlash(change.To.Name)),
+			RelPath:    change.To.Name,
+			Content:    []byte(currentContent),
+			AddedLines: addedLines,
+		})
+	}
+	return committedFiles, nil
+}
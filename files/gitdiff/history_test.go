@@ -0,0 +1,149 @@
+package gitdiff
+
+import (
+	"context"
+	"path/filepath"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/go-git/go-git/v5"
+	"github.com/go-git/go-git/v5/plumbing"
+	"github.com/go-git/go-git/v5/plumbing/object"
+)
+
+/**
+ * commitFile - helper used to write a file and commit it
+ */
+func commitFile(t *testing.T, rootPath string, fileName string, content string, commitMessage string) {
+	repository, err := git.PlainOpen(rootPath)
+	if err != nil {
+		t.Fatal(err)
+	}
+	worktree, _ := repository.Worktree()
+	writeFile(t, filepath.Join(rootPath, fileName), content)
+	worktree.Add(fileName)
+	_, err = worktree.Commit(commitMessage, &git.CommitOptions{
+		Author: &object.Signature{Name: "author", Email: "author@example.com", When: time.Now()},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestWalkCommits_WhenCommitsModifyFiles_VisitsAddedLinesNewestFirst(t *testing.T) {
+	//Given
+	rootPath := createRepository(t, map[string]string{"a.cls": "line1\nline2\n"})
+	commitFile(t, rootPath, "a.cls", "line1\nadded\nline2\n", "second")
+
+	//When
+	visitedChanges := []CommitChanges{}
+	err := WalkCommits(context.Background(), rootPath, 0, func(commitChanges CommitChanges) error {
+		visitedChanges = append(visitedChanges, commitChanges)
+		return nil
+	})
+
+	//Then
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if len(visitedChanges) != 2 {
+		t.Fatalf("Expected 2 commits to be visited, got %d", len(visitedChanges))
+	}
+	if visitedChanges[0].Author != "author" || visitedChanges[0].Email != "author@example.com" {
+		t.Errorf("Expected the newest commit first. Actual: %+v", visitedChanges[0])
+	}
+	expectedAddedLines := [][]LineRange{{{Start: 2, End: 2}}, {{Start: 1, End: 2}}}
+	for index, commitChanges := range visitedChanges {
+		if len(commitChanges.Files) != 1 || commitChanges.Files[0].RelPath != "a.cls" || commitChanges.Files[0].Path != filepath.Join(rootPath, "a.cls") {
+			t.Fatalf("Committed files mismatched. Actual: %+v", commitChanges.Files)
+		}
+		if !reflect.DeepEqual(commitChanges.Files[0].AddedLines, expectedAddedLines[index]) {
+			t.Errorf("Added lines mismatched.\n Actual %v\n Expected %v", commitChanges.Files[0].AddedLines, expectedAddedLines[index])
+		}
+	}
+}
+
+func TestWalkCommits_WhenMaxCommitsIsSet_VisitsNewestCommitsOnly(t *testing.T) {
+	//Given
+	rootPath := createRepository(t, map[string]string{"a.cls": "line1\n"})
+	commitFile(t, rootPath, "b.cls", "b\n", "second")
+	commitFile(t, rootPath, "c.cls", "c\n", "third")
+
+	//When
+	visitedFiles := []string{}
+	err := WalkCommits(context.Background(), rootPath, 2, func(commitChanges CommitChanges) error {
+		for _, committedFile := range commitChanges.Files {
+			visitedFiles = append(visitedFiles, committedFile.RelPath)
+		}
+		return nil
+	})
+
+	//Then
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if !reflect.DeepEqual(visitedFiles, []string{"c.cls", "b.cls"}) {
+		t.Errorf("Visited files mismatched. Actual %v", visitedFiles)
+	}
+}
+
+func TestWalkCommits_WhenMergeCommitChangesLines_VisitsLinesNotInAnyParent(t *testing.T) {
+	//Given
+	rootPath := createRepository(t, map[string]string{"a.cls": "line1\n"})
+	repository, _ := git.PlainOpen(rootPath)
+	worktree, _ := repository.Worktree()
+	head, _ := repository.Head()
+	baseHash := head.Hash()
+	commitAt := func(commitMessage string, when time.Time, parents ...plumbing.Hash) plumbing.Hash {
+		hash, err := worktree.Commit(commitMessage, &git.CommitOptions{
+			Author:  &object.Signature{Name: "author", Email: "author@example.com", When: when},
+			Parents: parents,
+		})
+		if err != nil {
+			t.Fatal(err)
+		}
+		return hash
+	}
+	now := time.Now()
+	writeFile(t, filepath.Join(rootPath, "a.cls"), "line1\nmain\n")
+	worktree.Add("a.cls")
+	mainHash := commitAt("main", now.Add(time.Minute), baseHash)
+	writeFile(t, filepath.Join(rootPath, "a.cls"), "line1\n")
+	writeFile(t, filepath.Join(rootPath, "b.cls"), "side\n")
+	worktree.Add("a.cls")
+	worktree.Add("b.cls")
+	sideHash := commitAt("side", now.Add(2*time.Minute), baseHash)
+	writeFile(t, filepath.Join(rootPath, "a.cls"), "line1\nmain\nevil\n")
+	worktree.Add("a.cls")
+	commitAt("merge", now.Add(3*time.Minute), mainHash, sideHash)
+
+	//When
+	visitedChanges := []CommitChanges{}
+	err := WalkCommits(context.Background(), rootPath, 1, func(commitChanges CommitChanges) error {
+		visitedChanges = append(visitedChanges, commitChanges)
+		return nil
+	})
+
+	//Then
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if len(visitedChanges) != 1 || len(visitedChanges[0].Files) != 1 || visitedChanges[0].Files[0].RelPath != "a.cls" {
+		t.Fatalf("Expected the merge commit to change a.cls only. Actual: %+v", visitedChanges)
+	}
+	if !reflect.DeepEqual(visitedChanges[0].Files[0].AddedLines, []LineRange{{Start: 3, End: 3}}) {
+		t.Errorf("Added lines mismatched.\n Actual %v\n Expected %v", visitedChanges[0].Files[0].AddedLines, []LineRange{{Start: 3, End: 3}})
+	}
+}
+
+func TestIntersectLineRanges_WhenRangesOverlap_ReturnsCommonLines(t *testing.T) {
+	//When
+	actualResult := intersectLineRanges([]LineRange{{Start: 1, End: 5}, {Start: 8, End: 9}}, []LineRange{{Start: 3, End: 8}})
+
+	//Then
+	expectedResult := []LineRange{{Start: 3, End: 5}, {Start: 8, End: 8}}
+	if !reflect.DeepEqual(actualResult, expectedResult) {
+		t.Errorf("Line ranges mismatched.\n Actual %v\n Expected %v", actualResult, expectedResult)
+	}
+}
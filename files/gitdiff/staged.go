@@ -0,0 +1,97 @@
+package gitdiff
+
+import (
+	"errors"
+	"io"
+	"path/filepath"
+
+	"github.com/go-git/go-git/v5"
+	"github.com/go-git/go-git/v5/plumbing"
+	"github.com/go-git/go-git/v5/plumbing/filemode"
+	"github.com/go-git/go-git/v5/plumbing/object"
+
+	"github.com/certinia/asist/errorhandler"
+	"github.com/certinia/asist/message"
+)
+
+// StagedFile is a file added or modified in the git index, its content which will be committed is read on demand
+type StagedFile struct {
+	// Path is the absolute path of the file in the working tree
+	Path string
+	// RelPath is the slash separated path of the file relative to the root of the working tree
+	RelPath    string
+	repository *git.Repository
+	hash       plumbing.Hash
+}
+
+/**
+ * GetStagedFiles - method used to list the files added or modified in the git index of the repository containing the root path.
+ *	Their content is read with Open from the staged blobs, not from the working tree, so unstaged changes are ignored.
+ */
+func GetStagedFiles(rootPath string) ([]StagedFile, error) {
+	repository, err := git.PlainOpenWithOptions(rootPath, &git.PlainOpenOptions{DetectDotGit: true})
+	if err != nil {
+		return nil, errorhandler.NewUserError(message.GetGitRepositoryError(rootPath, err))
+	}
+	worktree, err := repository.Worktree()
+	if err != nil {
+		return nil, errorhandler.NewUserError(message.GetGitRepositoryError(rootPath, err))
+	}
+	index, err := repository.Storer.Index()
+	if err != nil {
+		return nil, errorhandler.NewInternalError(message.GetGitIndexError(err))
+	}
+	headTree, err := getHeadTree(repository)
+	if err != nil {
+		return nil, errorhandler.NewUserError(message.GetGitRevisionError(string(plumbing.HEAD), err))
+	}
+
+	stagedFiles := []StagedFile{}
+	for _, entry := range index.Entries {
+		// Submodules have no content to scan, and conflicting entries (non zero stage) cannot be committed
+		if entry.Mode == filemode.Submodule || entry.Stage != 0 {
+			continue
+		}
+		if headTree != nil {
+			if headEntry, err := headTree.FindEntry(entry.Name); err == nil && headEntry.Hash == entry.Hash {
+				continue
+			}
+		}
+		stagedFiles = append(stagedFiles, StagedFile{
+			Path:       filepath.Join(worktree.Filesystem.Root(), filepath.FromSlash(entry.Name)),
+			RelPath:    entry.Name,
+			repository: repository,
+			hash:       entry.Hash,
+		})
+	}
+	return stagedFiles, nil
+}
+
+/**
+ * getHeadTree - method used to get the tree of the HEAD commit, nil before the first commit
+ */
+func getHeadTree(repository *git.Repository) (*object.Tree, error) {
+	head, err := repository.Head()
+	if errors.Is(err, plumbing.ErrReferenceNotFound) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	commit, err := repository.CommitObject(head.Hash())
+	if err != nil {
+		return nil, err
+	}
+	return commit.Tree()
+}
+
+/**
+ * Open - method used to open the staged content of the file, the reader must be closed
+ */
+func (f StagedFile) Open() (io.ReadCloser, error) {
+	blob, err := f.repository.BlobObject(f.hash)
+	if err != nil {
+		return nil, err
+	}
+	return blob.Reader()
+}
@@ -0,0 +1,79 @@
+package gitdiff
+
+import (
+	"io"
+	"path/filepath"
+	"testing"
+
+	"github.com/go-git/go-git/v5"
+)
+
+func TestGetStagedFiles_WhenChangesStaged_ReturnsStagedContent(t *testing.T) {
+	//Given
+	rootPath := createRepository(t, map[string]string{
+		"unchanged.cls": "public class A {\n}\n",
+		"changed.cls":   "before\n",
+	})
+	writeFile(t, filepath.Join(rootPath, "changed.cls"), "staged\n")
+	writeFile(t, filepath.Join(rootPath, "new.cls"), "new\n")
+	repository, err := git.PlainOpen(rootPath)
+	if err != nil {
+		t.Fatal(err)
+	}
+	worktree, _ := repository.Worktree()
+	worktree.Add("changed.cls")
+	worktree.Add("new.cls")
+	// Unstaged changes are not part of the commit
+	writeFile(t, filepath.Join(rootPath, "changed.cls"), "unstaged\n")
+
+	//When
+	actualResult, err := GetStagedFiles(rootPath)
+
+	//Then
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	expectedContents := map[string]string{"changed.cls": "staged\n", "new.cls": "new\n"}
+	if len(actualResult) != len(expectedContents) {
+		t.Fatalf("Staged files mismatched.\n Actual %v\n Expected %v", actualResult, expectedContents)
+	}
+	for _, stagedFile := range actualResult {
+		content := readStagedFile(t, stagedFile)
+		if content != expectedContents[stagedFile.RelPath] {
+			t.Errorf("Staged content of %s mismatched.\n Actual %q\n Expected %q", stagedFile.RelPath, content, expectedContents[stagedFile.RelPath])
+		}
+		if stagedFile.Path != filepath.Join(rootPath, stagedFile.RelPath) {
+			t.Errorf("Staged path mismatched.\n Actual %s\n Expected %s", stagedFile.Path, filepath.Join(rootPath, stagedFile.RelPath))
+		}
+	}
+}
+
+func TestGetStagedFiles_WhenNothingStaged_ReturnsNoFiles(t *testing.T) {
+	//Given
+	rootPath := createRepository(t, map[string]string{"a.cls": "a\n"})
+	writeFile(t, filepath.Join(rootPath, "a.cls"), "unstaged\n")
+
+	//When
+	actualResult, err := GetStagedFiles(rootPath)
+
+	//Then
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if len(actualResult) != 0 {
+		t.Errorf("Expected no staged files, got %v", actualResult)
+	}
+}
+
+func readStagedFile(t *testing.T, stagedFile StagedFile) string {
+	reader, err := stagedFile.Open()
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	defer reader.Close()
+	content, err := io.ReadAll(reader)
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	return string(content)
+}
@@ -0,0 +1,58 @@
+package files
+
+import (
+	"os"
+	"path/filepath"
+	"sync"
+)
+
+// repositoryRoots caches the git repository root found for each directory ("" when not in a repository)
+var repositoryRoots sync.Map
+
+/**
+ * GetRepoRelativePath - method used to get the slash separated path of a file relative to the root of its git repository,
+ *	so the path is the same wherever the repository is checked out. The file name is returned when the file is not in a repository.
+ */
+func GetRepoRelativePath(path string) string {
+	absPath, err := filepath.Abs(path)
+	if err != nil {
+		return filepath.Base(path)
+	}
+	repositoryRoot := getRepositoryRoot(filepath.Dir(absPath))
+	if repositoryRoot == "" {
+		return filepath.Base(absPath)
+	}
+	relPath, err := filepath.Rel(repositoryRoot, absPath)
+	if err != nil {
+		return filepath.Base(absPath)
+	}
+	return filepath.ToSlash(relPath)
+}
+
+/**
+ * GetRepositoryRoot - method used to get the root of the git repository containing the path, or an empty string outside of a repository
+ */
+func GetRepositoryRoot(path string) string {
+	absPath, err := filepath.Abs(path)
+	if err != nil {
+		return ""
+	}
+	return getRepositoryRoot(absPath)
+}
+
+/**
+ * getRepositoryRoot - method used to find the closest parent directory containing a .git folder or file
+ */
+func getRepositoryRoot(dir string) string {
+	if repositoryRoot, isCached := repositoryRoots.Load(dir); isCached {
+		return repositoryRoot.(string)
+	}
+	repositoryRoot := ""
+	if _, err := os.Stat(filepath.Join(dir, ".git")); err == nil {
+		repositoryRoot = dir
+	} else if parentDir := filepath.Dir(dir); parentDir != dir {
+		repositoryRoot = getRepositoryRoot(parentDir)
+	}
+	repositoryRoots.Store(dir, repositoryRoot)
+	return repositoryRoot
+}
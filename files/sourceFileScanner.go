@@ -2,9 +2,11 @@ package files
 
 import (
 	"bufio"
+	"io"
 	"os"
 	"regexp"
 	"strings"
+	"unicode/utf8"
 )
 
 /*
@@ -50,20 +52,54 @@ EXP: [RuleID1, RuleID2]
 */
 var selectBracketRegexp = regexp.MustCompile(`(\[.*\])`)
 
+// ParseOptions are the options used to split the content of a file into lines
+type ParseOptions struct {
+	// MaxLineLength is the maximum length in bytes of the text of a line, no limit when zero.
+	// Longer lines are truncated unless ChunkLongLines is set
+	MaxLineLength int
+	// ChunkLongLines splits the lines longer than MaxLineLength into several lines with the same line number
+	ChunkLongLines bool
+}
+
 /**
  * Read - method used to read the file content and store in file struct
  */
 func Read(filename string) (*File, error) {
-	var fileLines []Line
-	var ignoreSelectedLines []IgnoreSelected
+	return ReadWithOptions(filename, ParseOptions{})
+}
 
+/**
+ * ReadWithOptions - method used to read the file content and store in file struct, splitting the lines with the parse options
+ */
+func ReadWithOptions(filename string, parseOptions ParseOptions) (*File, error) {
 	readFile, err := os.Open(filename)
 	if err != nil {
 		return nil, err
 	}
 	defer readFile.Close()
-	fileScanner := bufio.NewScanner(readFile)
-	fileScanner.Split(bufio.ScanLines)
+	return ParseWithOptions(filename, readFile, parseOptions)
+}
+
+/**
+ * Parse - method used to store the content of a reader in file struct, e.g. for content which is not saved on disk.
+ *	The filename is only used to identify the file and is never opened.
+ */
+func Parse(filename string, reader io.Reader) (*File, error) {
+	return ParseWithOptions(filename, reader, ParseOptions{})
+}
+
+/**
+ * ParseWithOptions - method used to store the content of a reader in file struct, splitting the lines with the parse options.
+ *	Lines of any length are read. An error while reading does not fail the parse, the lines read before it are kept
+ *	and the error is stored in File.ReadError.
+ */
+func ParseWithOptions(filename string, reader io.Reader, parseOptions ParseOptions) (*File, error) {
+	var fileLines []Line
+	var ignoreSelectedLines []IgnoreSelected
+	var truncatedLines []int
+	var readErr error
+
+	fileReader := bufio.NewReader(reader)
 
 	lineNumber := 0
 	//Use as a flag to check line is commented or not
@@ -71,62 +107,108 @@ func Read(filename string) (*File, error) {
 	foundIgnoreMismatch := false
 	lineContent := ""
 
-	for fileScanner.Scan() {
+	for {
+		sourceLine, err := fileReader.ReadString('\n')
+		if err != nil && err != io.EOF {
+			readErr = err
+		}
+		// The last line is only kept when it is not empty, like a line ended by a new line character
+		if err != nil && sourceLine == "" {
+			break
+		}
 		lineNumber += 1
-		lineContent = fileScanner.Text()
-		isEndOfCommentedLine := false
-		//TRUE : IF isCommentedLine value is false
-		if !isCommentedLine {
-			isCommentedLine = startOfCommentedLineRegexp.MatchString(lineContent)
+		sourceLine = strings.TrimSuffix(strings.TrimSuffix(sourceLine, "\n"), "\r")
+		lineChunks := splitLine(sourceLine, parseOptions)
+		if len(lineChunks) == 1 && len(lineChunks[0].Text) < len(sourceLine) {
+			truncatedLines = append(truncatedLines, lineNumber)
 		}
-		//TRUE : IF isCommentedLine value is true or we have found comment is started
-		if isCommentedLine {
-			//Use as a flag to check commented line is end and use also in Line.IsCommentedLine
-			isEndOfCommentedLine = endOfCommentedLineRegexp.MatchString(lineContent)
-			//TRUE : if In the line comment end tag is present end of the line
-			//  SOME COMMENTED CODE OR WORDS -->
-			//  SOME COMMENTED CODE OR WORDS */ or **/ or  (n number of star)*/
-			if isEndOfCommentedLine {
-				isCommentedLine = false
-				//TRUE : if isCommentedLine flag value is true and In the line comment end tag is present between of the line
-				//  SOME COMMENTED CODE OR WORDS --> Executable Code
-				//  SOME COMMENTED CODE OR WORDS */ or **/ or  (n number of star)*/ Executable Code
-			} else if endOfcommentedLineBetweenRegexp.MatchString(lineContent) {
-				isCommentedLine = false
+		for _, lineChunk := range lineChunks {
+			lineContent = lineChunk.Text
+			isEndOfCommentedLine := false
+			//TRUE : IF isCommentedLine value is false
+			if !isCommentedLine {
+				isCommentedLine = startOfCommentedLineRegexp.MatchString(lineContent)
 			}
-		}
-
-		isCommentedLine := (isCommentedLine || isEndOfCommentedLine || singleLineCommentedRegexp.MatchString(lineContent))
-
-		if isCommentedLine && !foundIgnoreMismatch {
-			//IF : "asist-ignore-end:[RuleIDs]" Present in lineContent
-			if multiLineFalsePositiveEndPatternRegexp.MatchString(lineContent) {
-				foundIgnoreMismatch = (len(ignoreSelectedLines) > 0 && ignoreSelectedLines[len(ignoreSelectedLines)-1].EndLine != -1) || len(ignoreSelectedLines) == 0
-				if !foundIgnoreMismatch {
-					ignoreSelectedLines[len(ignoreSelectedLines)-1].EndLine = lineNumber
+			//TRUE : IF isCommentedLine value is true or we have found comment is started
+			if isCommentedLine {
+				//Use as a flag to check commented line is end and use also in Line.IsCommentedLine
+				isEndOfCommentedLine = endOfCommentedLineRegexp.MatchString(lineContent)
+				//TRUE : if In the line comment end tag is present end of the line
+				//  SOME COMMENTED CODE OR WORDS -->
+				//  SOME COMMENTED CODE OR WORDS */ or **/ or  (n number of star)*/
+				if isEndOfCommentedLine {
+					isCommentedLine = false
+					//TRUE : if isCommentedLine flag value is true and In the line comment end tag is present between of the line
+					//  SOME COMMENTED CODE OR WORDS --> Executable Code
+					//  SOME COMMENTED CODE OR WORDS */ or **/ or  (n number of star)*/ Executable Code
+				} else if endOfcommentedLineBetweenRegexp.MatchString(lineContent) {
+					isCommentedLine = false
 				}
-			} else {
-				falsePositiveRange := multiLineFalsePositiveBeginPatternRegexp.FindStringIndex(lineContent)
+			}
 
-				if len(falsePositiveRange) > 0 {
-					foundIgnoreMismatch = len(ignoreSelectedLines) > 0 && ignoreSelectedLines[len(ignoreSelectedLines)-1].EndLine == -1
+			isCommentedLine := (isCommentedLine || isEndOfCommentedLine || singleLineCommentedRegexp.MatchString(lineContent))
 
+			if isCommentedLine && !foundIgnoreMismatch {
+				//IF : "asist-ignore-end:[RuleIDs]" Present in lineContent
+				if multiLineFalsePositiveEndPatternRegexp.MatchString(lineContent) {
+					foundIgnoreMismatch = (len(ignoreSelectedLines) > 0 && ignoreSelectedLines[len(ignoreSelectedLines)-1].EndLine != -1) || len(ignoreSelectedLines) == 0
 					if !foundIgnoreMismatch {
-						ignoreSelectedLines = append(ignoreSelectedLines, getIgnoreSelectedLine(falsePositiveRange, lineContent, lineNumber))
+						ignoreSelectedLines[len(ignoreSelectedLines)-1].EndLine = lineNumber
+					}
+				} else {
+					falsePositiveRange := multiLineFalsePositiveBeginPatternRegexp.FindStringIndex(lineContent)
+
+					if len(falsePositiveRange) > 0 {
+						foundIgnoreMismatch = len(ignoreSelectedLines) > 0 && ignoreSelectedLines[len(ignoreSelectedLines)-1].EndLine == -1
+
+						if !foundIgnoreMismatch {
+							ignoreSelectedLines = append(ignoreSelectedLines, getIgnoreSelectedLine(falsePositiveRange, lineContent, lineNumber))
+						}
 					}
 				}
 			}
+			fileLines = append(fileLines, Line{
+				LineNumber:      lineNumber,
+				Text:            lineContent,
+				IsCommentedLine: isCommentedLine,
+				ColumnOffset:    lineChunk.ColumnOffset,
+			})
+		}
+		if err != nil {
+			break
 		}
-		fileLines = append(fileLines, Line{
-			LineNumber:      lineNumber,
-			Text:            lineContent,
-			IsCommentedLine: isCommentedLine,
-		})
 	}
-	masterFile := File{Lines: fileLines, FileName: filename, IgnoresSelected: ignoreSelectedLines}
+	masterFile := File{Lines: fileLines, FileName: filename, IgnoresSelected: ignoreSelectedLines, TruncatedLines: truncatedLines, ReadError: readErr}
 	return &masterFile, nil
 }
 
+/**
+ * splitLine - method used to split the text of a line longer than the maximum line length into chunks, or to truncate it.
+ *	Chunks never split a multi-byte character.
+ */
+func splitLine(text string, parseOptions ParseOptions) []Line {
+	if parseOptions.MaxLineLength <= 0 || len(text) <= parseOptions.MaxLineLength {
+		return []Line{{Text: text}}
+	}
+	var lineChunks []Line
+	for columnOffset := 0; columnOffset < len(text); {
+		chunkEnd := columnOffset + parseOptions.MaxLineLength
+		if chunkEnd >= len(text) {
+			chunkEnd = len(text)
+		} else {
+			for chunkEnd > columnOffset+1 && !utf8.RuneStart(text[chunkEnd]) {
+				chunkEnd--
+			}
+		}
+		lineChunks = append(lineChunks, Line{Text: text[columnOffset:chunkEnd], ColumnOffset: columnOffset})
+		if !parseOptions.ChunkLongLines {
+			break
+		}
+		columnOffset = chunkEnd
+	}
+	return lineChunks
+}
+
 /**
  * getIgnoreSelectedLine - method to get the lines numbers of code with RuleIds which are marked as false positive
  */
@@ -3,12 +3,18 @@ package finding
 import (
 	"crypto/sha256"
 	"fmt"
+	"strings"
+	"time"
 
+	"github.com/certinia/asist/files"
 	"github.com/certinia/asist/rules"
 )
 
 type RecordType string
 
+// Number of lines before and after a finding used in its fingerprint
+const fingerprintContextLines = 2
+
 const (
 	BaselineFinding RecordType = "Finding"
 	BaselineConfig  RecordType = "Config"
@@ -16,6 +22,7 @@ const (
 
 type BaselineOutputContent struct {
 	FindingID       string             `json:"FindingID"`
+	Fingerprint     string             `json:"Fingerprint"`
 	IsCustom        bool               `json:"IsCustom"`
 	IsFalsePositive bool               `json:"IsFalsePositive"`
 	Id              rules.RuleID       `json:"RuleID"`
@@ -36,7 +43,17 @@ type Finding struct {
 	Description  string             `json:"Description"`
 	Severity     rules.Severity     `json:"Severity"`
 	RuleCategory rules.RuleCategory `json:"RuleCategory"`
+	Fingerprint  string             `json:"Fingerprint,omitempty"`
 	Occurrence   rules.Occurrence   `json:"Occurrence"`
+	Commit       *Commit            `json:"Commit,omitempty"`
+}
+
+// Commit is the git commit which introduced the line of a finding, only set when scanning the git history or with --blame
+type Commit struct {
+	Hash   string    `json:"Hash"`
+	Author string    `json:"Author"`
+	Email  string    `json:"Email"`
+	Date   time.Time `json:"Date"`
 }
 
 /**
@@ -44,16 +61,140 @@ type Finding struct {
  */
 func (finding *Finding) CreateFindingID() string {
 	contentToHash := fmt.Sprintf("%s-%s", finding.ID, finding.Occurrence.CreateHashableString())
+	return createHash(contentToHash)
+}
 
+/**
+ * GetFingerprint - method used to get the fingerprint of a finding, or its ID for the findings without fingerprint,
+ *	e.g. in the outputs of older versions
+ */
+func (finding *Finding) GetFingerprint() string {
+	if finding.Fingerprint != "" {
+		return finding.Fingerprint
+	}
+	return finding.CreateFindingID()
+}
+
+/**
+ * SetFingerprints - method used to set the fingerprint of the findings of a single file.
+ *	Unlike the finding ID, the fingerprint does not change when lines are added above the finding or when the
+ *	repository is checked out elsewhere: it is created from the repository relative path, the rule ID, and the
+ *	normalized content of the line and its surrounding lines. Identical findings are told apart by their occurrence index.
+ */
+func SetFingerprints(findings []Finding, file *files.File) {
+	relPath := files.GetRepoRelativePath(file.FileName)
+	occurrenceIndexes := map[string]int{}
+	for index := range findings {
+		occurrence := findings[index].Occurrence
+		contentToHash := fmt.Sprintf("%s-%s-%s", relPath, findings[index].ID, normalizeLine(occurrence.LineContent))
+		for _, surroundingLine := range file.GetSurroundingLines(occurrence.LineNumber, fingerprintContextLines) {
+			// Blank lines are skipped so adding or removing them around the finding does not change the fingerprint
+			if normalizedLine := normalizeLine(surroundingLine); normalizedLine != "" {
+				contentToHash += "-" + normalizedLine
+			}
+		}
+		occurrenceIndex := occurrenceIndexes[contentToHash]
+		occurrenceIndexes[contentToHash]++
+		findings[index].Fingerprint = createHash(fmt.Sprintf("%s-%d", contentToHash, occurrenceIndex))
+	}
+}
+
+/**
+ * normalizeLine - method used to collapse the whitespaces of a line so indentation changes do not change fingerprints
+ */
+func normalizeLine(line string) string {
+	return strings.Join(strings.Fields(line), " ")
+}
+
+func createHash(contentToHash string) string {
 	hash := sha256.New()
 	hash.Write([]byte(contentToHash))
 	bs := hash.Sum(nil)[0:11]
 	return fmt.Sprintf("%x", bs)
 }
 
+// Statuses and reasons of the diagnostics reported for files which could not be fully scanned
+const (
+	DiagnosticStatusSkipped     = "skipped"
+	DiagnosticStatusTruncated   = "truncated"
+	DiagnosticStatusPartial     = "partial"
+	DiagnosticReasonTimeout     = "timeout"
+	DiagnosticReasonLineTooLong = "line too long"
+	DiagnosticReasonReadError   = "read error"
+)
+
+// Diagnostic reports a file which could not be fully scanned, e.g. "skipped: timeout"
+type Diagnostic struct {
+	FileName string       `json:"File"`
+	RuleID   rules.RuleID `json:"RuleID,omitempty"`
+	Status   string       `json:"Status"`
+	Reason   string       `json:"Reason"`
+	Message  string       `json:"Message"`
+}
+
 type Output struct {
-	Count           int       `json:"Count"`
-	ScanStartedTime string    `json:"Started"`
-	ScanEndingTime  string    `json:"Ended"`
-	Results         []Finding `json:"Result"`
+	Count           int          `json:"Count"`
+	ScanStartedTime string       `json:"Started"`
+	ScanEndingTime  string       `json:"Ended"`
+	Results         []Finding    `json:"Result"`
+	Diagnostics     []Diagnostic `json:"Diagnostics,omitempty"`
+}
+
+// DiffOutput contains the findings which appeared, were fixed or persisted between two scans
+type DiffOutput struct {
+	New        []Finding `json:"New"`
+	Fixed      []Finding `json:"Fixed"`
+	Persisting []Finding `json:"Persisting"`
+}
+
+// FingerprintMatch is the result of matching the fingerprints of current findings with the fingerprints of previous findings
+type FingerprintMatch struct {
+	// New are the indexes of the current fingerprints which are not in the previous ones
+	New []int
+	// Persisting are the indexes of the current fingerprints which are in the previous ones
+	Persisting []int
+	// Fixed are the indexes of the previous fingerprints which are not in the current ones
+	Fixed []int
+}
+
+/**
+ * MatchFingerprints - method used to match current fingerprints with previous ones, e.g. of a baseline or an older scan.
+ *	Each previous fingerprint can only match one current fingerprint, so duplicated findings are counted:
+ *	the first occurrences of a fingerprint are matched and the extra ones are new or fixed.
+ */
+func MatchFingerprints(previousFingerprints []string, currentFingerprints []string) FingerprintMatch {
+	match := FingerprintMatch{New: []int{}, Persisting: []int{}, Fixed: []int{}}
+	remainingCounts := map[string]int{}
+	for _, fingerprint := range previousFingerprints {
+		remainingCounts[fingerprint]++
+	}
+	matchedCounts := map[string]int{}
+	for index, fingerprint := range currentFingerprints {
+		if remainingCounts[fingerprint] > 0 {
+			remainingCounts[fingerprint]--
+			matchedCounts[fingerprint]++
+			match.Persisting = append(match.Persisting, index)
+			continue
+		}
+		match.New = append(match.New, index)
+	}
+	for index, fingerprint := range previousFingerprints {
+		if matchedCounts[fingerprint] > 0 {
+			matchedCounts[fingerprint]--
+			continue
+		}
+		match.Fixed = append(match.Fixed, index)
+	}
+	return match
+}
+
+/**
+ * GetFingerprints - method used to get the fingerprints of findings, in the same order
+ */
+func GetFingerprints(findings []Finding) []string {
+	fingerprints := make([]string, len(findings))
+	for index := range findings {
+		fingerprints[index] = findings[index].GetFingerprint()
+	}
+	return fingerprints
 }
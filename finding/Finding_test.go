@@ -1,8 +1,10 @@
 package finding
 
 import (
+	"reflect"
 	"testing"
 
+	"github.com/certinia/asist/files"
 	"github.com/certinia/asist/rules"
 )
 
@@ -28,3 +30,61 @@ func TestCreateFindingID(t *testing.T) {
 		t.Errorf("Finding ID should not be empty!")
 	}
 }
+
+func TestSetFingerprints_WhenLinesAddedAbove_ReturnsSameFingerprint(t *testing.T) {
+	// Given
+	lines := []string{"public class A {", "  String password = 'secret';", "}"}
+	movedLines := append([]string{"// new comment", ""}, "public class A {", "    String   password = 'secret';", "}")
+	findings := []Finding{{ID: "HardcodedCredentials", Occurrence: rules.Occurrence{LineContent: lines[1], LineNumber: 2}}}
+	movedFindings := []Finding{{ID: "HardcodedCredentials", Occurrence: rules.Occurrence{LineContent: movedLines[3], LineNumber: 4}}}
+
+	// When
+	SetFingerprints(findings, createFile("/checkout1/A.cls", lines))
+	SetFingerprints(movedFindings, createFile("/checkout2/A.cls", movedLines))
+
+	// Then
+	if findings[0].Fingerprint == "" || findings[0].Fingerprint != movedFindings[0].Fingerprint {
+		t.Errorf("Fingerprint should not change when the finding moves. Actual: %s, Expected: %s", movedFindings[0].Fingerprint, findings[0].Fingerprint)
+	}
+}
+
+func TestSetFingerprints_WhenDuplicateFindings_ReturnsDifferentFingerprints(t *testing.T) {
+	// Given
+	lines := []string{"x = a.innerHTML + b.innerHTML;"}
+	findings := []Finding{
+		{ID: "XSSDomHtml", Occurrence: rules.Occurrence{LineContent: lines[0], LineNumber: 1, ColumnRange: []int{6, 15}}},
+		{ID: "XSSDomHtml", Occurrence: rules.Occurrence{LineContent: lines[0], LineNumber: 1, ColumnRange: []int{20, 29}}},
+		{ID: "XSSLabel", Occurrence: rules.Occurrence{LineContent: lines[0], LineNumber: 1, ColumnRange: []int{6, 15}}},
+	}
+
+	// When
+	SetFingerprints(findings, createFile("/src/a.js", lines))
+
+	// Then
+	if findings[0].Fingerprint == findings[1].Fingerprint || findings[0].Fingerprint == findings[2].Fingerprint {
+		t.Errorf("Fingerprints should be unique. Actual: %+v", findings)
+	}
+}
+
+func createFile(fileName string, lines []string) *files.File {
+	file := files.File{FileName: fileName}
+	for index, line := range lines {
+		file.Lines = append(file.Lines, files.Line{LineNumber: index + 1, Text: line})
+	}
+	return &file
+}
+
+func TestMatchFingerprints_WhenFingerprintsAreDuplicated_MatchesEachPreviousFingerprintOnce(t *testing.T) {
+	//Given
+	previousFingerprints := []string{"a", "b", "b", "c"}
+	currentFingerprints := []string{"b", "a", "d", "a"}
+
+	//When
+	match := MatchFingerprints(previousFingerprints, currentFingerprints)
+
+	//Then
+	expected := FingerprintMatch{New: []int{2, 3}, Persisting: []int{0, 1}, Fixed: []int{2, 3}}
+	if !reflect.DeepEqual(match, expected) {
+		t.Errorf("Match mismatched.\n Actual %+v\n Expected %+v", match, expected)
+	}
+}
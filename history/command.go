@@ -0,0 +1,62 @@
+package history
+
+import (
+	"context"
+	"errors"
+	"os"
+	"os/signal"
+
+	"github.com/certinia/asist/config"
+	"github.com/certinia/asist/errorhandler"
+	"github.com/certinia/asist/files"
+	"github.com/certinia/asist/message"
+	"github.com/certinia/asist/output"
+	"github.com/certinia/asist/parser/options"
+	"github.com/certinia/asist/scanner"
+)
+
+// Command is the asist history subcommand, it scans the lines added by the past commits of the repository
+type Command struct {
+	MaxCommits int `long:"max-commits" description:"Maximum number of commits to scan, newest first (all commits by default)"`
+	Args       struct {
+		Path string `positional-arg-name:"Path" description:"Folder inside the git repository (defaults to the current folder)"`
+	} `positional-args:"yes"`
+}
+
+/**
+ * Execute - method used to scan the lines added by the commits reachable from HEAD with the rules given with --rules,
+ *	or the rules enabled in the config, and write the findings with their commits in the output formats
+ */
+func (c *Command) Execute(args []string) error {
+	if c.MaxCommits < 0 {
+		return errorhandler.NewUserError(message.GetInvalidMaxCommitsError(c.MaxCommits))
+	}
+	if err := options.SetupOutputTargets(); err != nil {
+		return err
+	}
+	rootPath := c.Args.Path
+	if rootPath == "" {
+		rootPath = "."
+	}
+	opts := options.GetOptions()
+	configFilePath := opts.ConfigFile
+	if repositoryRoot := files.GetRepositoryRoot(rootPath); configFilePath == "" && repositoryRoot != "" {
+		configFilePath = config.FindConfigFile(repositoryRoot)
+	}
+	configFile, err := config.Load(configFilePath)
+	if err != nil {
+		return err
+	}
+
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt)
+	defer stop()
+	finalResult, err := ScanHistory(ctx, rootPath, configFile, scanner.NewScanOptions(opts), c.MaxCommits)
+	if errors.Is(err, context.Canceled) {
+		return errorhandler.NewUserError(message.GetScanCanceledError(err))
+	}
+	if err != nil {
+		return err
+	}
+	output.DisplayHistoryOutput(finalResult)
+	return nil
+}
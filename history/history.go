@@ -0,0 +1,60 @@
+// Package history scans the lines added by the past commits of a git repository, e.g. to find the credentials which remain in its history
+package history
+
+import (
+	"bytes"
+	"context"
+
+	"github.com/certinia/asist/config"
+	"github.com/certinia/asist/engine"
+	"github.com/certinia/asist/files"
+	"github.com/certinia/asist/files/gitdiff"
+	"github.com/certinia/asist/finding"
+)
+
+/**
+ * ScanHistory - method used to run the rules on the lines added by the commits reachable from HEAD in the repository containing the root path,
+ *	newest first and at most maxCommits commits (all of them when zero). Each finding has the commit which added its line.
+ *	The files excluded by the config are not scanned.
+ */
+func ScanHistory(ctx context.Context, rootPath string, cfg *config.Config, scanOptions engine.ScanOptions, maxCommits int) (*finding.Output, error) {
+	// The content of a path changes with each commit, so the findings cannot be cached per path
+	scanOptions.Cache = nil
+	var commitContents map[string][]byte
+	scanOptions.ReadFile = func(path string, parseOptions files.ParseOptions) (*files.File, error) {
+		return files.ParseWithOptions(path, bytes.NewReader(commitContents[path]), parseOptions)
+	}
+	scanEngine, err := engine.NewScanner(cfg, scanOptions)
+	if err != nil {
+		return nil, err
+	}
+
+	finalResult := &finding.Output{Results: []finding.Finding{}}
+	err = gitdiff.WalkCommits(ctx, rootPath, maxCommits, func(commitChanges gitdiff.CommitChanges) error {
+		commitContents = map[string][]byte{}
+		changedLines := gitdiff.ChangedLines{}
+		filePaths := []string{}
+		for _, committedFile := range commitChanges.Files {
+			commitContents[committedFile.Path] = committedFile.Content
+			changedLines[committedFile.Path] = committedFile.AddedLines
+			filePaths = append(filePaths, committedFile.Path)
+		}
+		filePaths = cfg.FilterExcludedFilesAndFolders(filePaths)
+		commitResult, err := scanEngine.ScanFiles(ctx, filePaths)
+		if err != nil {
+			return err
+		}
+		commit := &finding.Commit{Hash: commitChanges.Hash, Author: commitChanges.Author, Email: commitChanges.Email, Date: commitChanges.Date}
+		for _, result := range engine.FilterChangedFindings(commitResult, changedLines).Results {
+			result.Commit = commit
+			finalResult.Results = append(finalResult.Results, result)
+		}
+		finalResult.Diagnostics = append(finalResult.Diagnostics, commitResult.Diagnostics...)
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	finalResult.Count = len(finalResult.Results)
+	return finalResult, nil
+}
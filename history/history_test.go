@@ -0,0 +1,59 @@
+package history
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/go-git/go-git/v5"
+	"github.com/go-git/go-git/v5/plumbing/object"
+
+	"github.com/certinia/asist/engine"
+	"github.com/certinia/asist/rules"
+)
+
+/**
+ * commitFile - helper used to write a file and commit it, returns the commit hash
+ */
+func commitFile(t *testing.T, worktree *git.Worktree, rootPath string, fileName string, content string) string {
+	if err := os.WriteFile(filepath.Join(rootPath, fileName), []byte(content), 0600); err != nil {
+		t.Fatal(err)
+	}
+	worktree.Add(fileName)
+	hash, err := worktree.Commit("commit "+fileName, &git.CommitOptions{
+		Author: &object.Signature{Name: "author", Email: "author@example.com", When: time.Now()},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	return hash.String()
+}
+
+func TestScanHistory_WhenFindingWasRemoved_ReturnsFindingWithCommitWhichAddedIt(t *testing.T) {
+	//Given
+	rootPath := t.TempDir()
+	repository, err := git.PlainInit(rootPath, false)
+	if err != nil {
+		t.Fatal(err)
+	}
+	worktree, _ := repository.Worktree()
+	addingHash := commitFile(t, worktree, rootPath, "Foo.cls", "public class Foo {\n}\n")
+	commitFile(t, worktree, rootPath, "Foo.cls", "// header\npublic class Foo {\n}\n")
+	commitFile(t, worktree, rootPath, "Foo.cls", "public with sharing class Foo {\n}\n")
+
+	//When
+	actualResult, err := ScanHistory(context.Background(), rootPath, nil, engine.ScanOptions{Rules: []rules.RuleID{"ApexClassNoSharing"}, Jobs: 1}, 0)
+
+	//Then
+	if err != nil {
+		t.Fatalf("Should not return any error! %v", err)
+	}
+	if actualResult.Count != 1 || len(actualResult.Results) != 1 {
+		t.Fatalf("Expected only the commit which added the finding to report it. Actual: %+v", actualResult.Results)
+	}
+	if commit := actualResult.Results[0].Commit; commit == nil || commit.Hash != addingHash || commit.Author != "author" {
+		t.Errorf("Commit of the finding mismatched. Actual %+v, expected hash %s", commit, addingHash)
+	}
+}
@@ -2,6 +2,7 @@ package test
 
 import (
 	"bytes"
+	"context"
 	"encoding/json"
 	"reflect"
 	"strings"
@@ -31,7 +32,7 @@ func TestLightningImproperCSSLoadRule(t *testing.T) {
 
 	createData("./src", security.LightningImproperCSSLoadRuleID, "")
 	//When
-	actualResult, _ := scanner.RunRulesOnFiles(filePaths, ruleInstances)
+	actualResult, _ := scanner.RunRulesOnFiles(context.Background(), filePaths, ruleInstances, nil)
 
 	//then
 	if !reflect.DeepEqual(expectedResult, projectOutputToPartial(*actualResult)) {
@@ -57,7 +58,7 @@ func TestInsecureEndpointRule(t *testing.T) {
 
 	createData("./src", security.InsecureEndpointRuleID, "")
 	//When
-	actualResult, _ := scanner.RunRulesOnFiles(filePaths, ruleInstances)
+	actualResult, _ := scanner.RunRulesOnFiles(context.Background(), filePaths, ruleInstances, nil)
 
 	//then
 	if !reflect.DeepEqual(expectedResult, projectOutputToPartial(*actualResult)) {
@@ -83,7 +84,7 @@ func TestAuraComponentCssExposedRule(t *testing.T) {
 	createData("./src", security.AuraComponentCssExposedRuleID, "")
 
 	//When
-	actualResult, _ := scanner.RunRulesOnFiles(filePaths, ruleInstances)
+	actualResult, _ := scanner.RunRulesOnFiles(context.Background(), filePaths, ruleInstances, nil)
 
 	//then
 	if !reflect.DeepEqual(expectedResult, projectOutputToPartial(*actualResult)) {
@@ -109,7 +110,7 @@ func TestSensitiveInfoInDebugRule(t *testing.T) {
 	createData("./src", security.SensitiveInfoInDebugRuleID, "")
 
 	//When
-	actualResult, _ := scanner.RunRulesOnFiles(filePaths, ruleInstances)
+	actualResult, _ := scanner.RunRulesOnFiles(context.Background(), filePaths, ruleInstances, nil)
 
 	//then
 	if !reflect.DeepEqual(expectedResult, projectOutputToPartial(*actualResult)) {
@@ -135,7 +136,7 @@ func TestJSNotInStaticResourceRule(t *testing.T) {
 	createData("./src", security.JSNotInStaticResourceRuleID, "")
 
 	//When
-	actualResult, _ := scanner.RunRulesOnFiles(filePaths, ruleInstances)
+	actualResult, _ := scanner.RunRulesOnFiles(context.Background(), filePaths, ruleInstances, nil)
 
 	//then
 	if !reflect.DeepEqual(expectedResult, projectOutputToPartial(*actualResult)) {
@@ -156,7 +157,7 @@ func TestExposedMessageChannelRule(t *testing.T) {
 	createData("./src", security.ExposedMessageChannelRuleID, "")
 
 	//When
-	actualResult, _ := scanner.RunRulesOnFiles(filePaths, ruleInstances)
+	actualResult, _ := scanner.RunRulesOnFiles(context.Background(), filePaths, ruleInstances, nil)
 
 	//then
 	if !reflect.DeepEqual(expectedResult, projectOutputToPartial(*actualResult)) {
@@ -178,7 +179,7 @@ func TestProtectedCustomSettingRule(t *testing.T) {
 	createData("./src", security.ProtectedCustomSettingRuleID, "")
 
 	//When
-	actualResult, _ := scanner.RunRulesOnFiles(filePaths, ruleInstances)
+	actualResult, _ := scanner.RunRulesOnFiles(context.Background(), filePaths, ruleInstances, nil)
 
 	//then
 	if !reflect.DeepEqual(expectedResult, projectOutputToPartial(*actualResult)) {
@@ -201,7 +202,7 @@ func TestXssEscapeFalseRule(t *testing.T) {
 	createData("./src", security.XSSEscapeFalseRuleID, "")
 
 	//When
-	actualResult, _ := scanner.RunRulesOnFiles(filePaths, ruleInstances)
+	actualResult, _ := scanner.RunRulesOnFiles(context.Background(), filePaths, ruleInstances, nil)
 
 	//then
 	if !reflect.DeepEqual(expectedResult, projectOutputToPartial(*actualResult)) {
@@ -229,7 +230,7 @@ func TestXssLabelRule(t *testing.T) {
 	createData("./src", security.XSSLabelRuleID, "")
 
 	//When
-	actualResult, _ := scanner.RunRulesOnFiles(filePaths, ruleInstances)
+	actualResult, _ := scanner.RunRulesOnFiles(context.Background(), filePaths, ruleInstances, nil)
 
 	//then
 	if !reflect.DeepEqual(expectedResult, projectOutputToPartial(*actualResult)) {
@@ -252,7 +253,7 @@ func TestEmailInjectionRule(t *testing.T) {
 	createData("./src", security.EmailInjectionRuleID, "")
 
 	//When
-	actualResult, _ := scanner.RunRulesOnFiles(filePaths, ruleInstances)
+	actualResult, _ := scanner.RunRulesOnFiles(context.Background(), filePaths, ruleInstances, nil)
 
 	//then
 	if !reflect.DeepEqual(expectedResult, projectOutputToPartial(*actualResult)) {
@@ -274,7 +275,7 @@ func TestRichTextRule(t *testing.T) {
 	createData("./src", security.XSSIsRichTextRuleID, "")
 
 	//When
-	actualResult, _ := scanner.RunRulesOnFiles(filePaths, ruleInstances)
+	actualResult, _ := scanner.RunRulesOnFiles(context.Background(), filePaths, ruleInstances, nil)
 
 	//then
 	if !reflect.DeepEqual(expectedResult, projectOutputToPartial(*actualResult)) {
@@ -300,7 +301,7 @@ func TestXSSDomHtmlRule(t *testing.T) {
 	createData("./src", security.XSSDomHtmlRuleID, "")
 
 	//When
-	actualResult, _ := scanner.RunRulesOnFiles(filePaths, ruleInstances)
+	actualResult, _ := scanner.RunRulesOnFiles(context.Background(), filePaths, ruleInstances, nil)
 
 	//then
 	if !reflect.DeepEqual(expectedResult, projectOutputToPartial(*actualResult)) {
@@ -326,7 +327,7 @@ func TestXssTooltipRule(t *testing.T) {
 	createData("./src", security.XSSTooltipRuleID, "")
 
 	//When
-	actualResult, _ := scanner.RunRulesOnFiles(filePaths, ruleInstances)
+	actualResult, _ := scanner.RunRulesOnFiles(context.Background(), filePaths, ruleInstances, nil)
 	//then
 	if !reflect.DeepEqual(expectedResult, projectOutputToPartial(*actualResult)) {
 		actualResultJson, _ := json.MarshalIndent(projectOutputToPartial(*actualResult), "", "  ")
@@ -347,7 +348,7 @@ func TestXssAuraUnescapedHtmlRule(t *testing.T) {
 	createData("./src", security.XSSAuraUnescapedHtmlRuleID, "")
 
 	//When
-	actualResult, _ := scanner.RunRulesOnFiles(filePaths, ruleInstances)
+	actualResult, _ := scanner.RunRulesOnFiles(context.Background(), filePaths, ruleInstances, nil)
 
 	//then
 	if !reflect.DeepEqual(expectedResult, projectOutputToPartial(*actualResult)) {
@@ -370,7 +371,7 @@ func TestXssCurrentPageParameterRule(t *testing.T) {
 	createData("./src", security.XSSCurrentPageParametersRuleID, "")
 
 	//When
-	actualResult, _ := scanner.RunRulesOnFiles(filePaths, ruleInstances)
+	actualResult, _ := scanner.RunRulesOnFiles(context.Background(), filePaths, ruleInstances, nil)
 
 	//then
 	if !reflect.DeepEqual(expectedResult, projectOutputToPartial(*actualResult)) {
@@ -393,7 +394,7 @@ func TestXssOccurrenceSearchRule(t *testing.T) {
 	createData("./src", security.XSSLocationSearchRuleID, "")
 
 	//When
-	actualResult, _ := scanner.RunRulesOnFiles(filePaths, ruleInstances)
+	actualResult, _ := scanner.RunRulesOnFiles(context.Background(), filePaths, ruleInstances, nil)
 
 	//then
 	if !reflect.DeepEqual(expectedResult, projectOutputToPartial(*actualResult)) {
@@ -416,7 +417,7 @@ func TestXssSrcdocRule(t *testing.T) {
 	createData("./src", security.XSSSrcDocRuleID, "")
 
 	//When
-	actualResult, _ := scanner.RunRulesOnFiles(filePaths, ruleInstances)
+	actualResult, _ := scanner.RunRulesOnFiles(context.Background(), filePaths, ruleInstances, nil)
 
 	//then
 	if !reflect.DeepEqual(expectedResult, projectOutputToPartial(*actualResult)) {
@@ -439,7 +440,7 @@ func TestXssMergeField(t *testing.T) {
 	createData("./src", security.XSSMergeFieldRuleID, "")
 
 	//When
-	actualResult, _ := scanner.RunRulesOnFiles(filePaths, ruleInstances)
+	actualResult, _ := scanner.RunRulesOnFiles(context.Background(), filePaths, ruleInstances, nil)
 
 	//then
 	if !reflect.DeepEqual(expectedResult, projectOutputToPartial(*actualResult)) {
@@ -461,7 +462,7 @@ func TestXssFormActionRule(t *testing.T) {
 	createData("./src", security.XSSFormActionRuleID, "")
 
 	//When
-	actualResult, _ := scanner.RunRulesOnFiles(filePaths, ruleInstances)
+	actualResult, _ := scanner.RunRulesOnFiles(context.Background(), filePaths, ruleInstances, nil)
 
 	//then
 	if !reflect.DeepEqual(expectedResult, projectOutputToPartial(*actualResult)) {
@@ -484,7 +485,7 @@ func TestXSSApexChartRule(t *testing.T) {
 	createData("./src", security.XSSApexChartRuleID, "")
 
 	//When
-	actualResult, _ := scanner.RunRulesOnFiles(filePaths, ruleInstances)
+	actualResult, _ := scanner.RunRulesOnFiles(context.Background(), filePaths, ruleInstances, nil)
 
 	//then
 	if !reflect.DeepEqual(expectedResult, projectOutputToPartial(*actualResult)) {
@@ -507,7 +508,7 @@ func TestXssEscapeFalseInJSRule(t *testing.T) {
 	createData("./src", security.XSSEscapeFalseInJSRuleID, "")
 
 	//When
-	actualResult, _ := scanner.RunRulesOnFiles(filePaths, ruleInstances)
+	actualResult, _ := scanner.RunRulesOnFiles(context.Background(), filePaths, ruleInstances, nil)
 
 	//then
 	if !reflect.DeepEqual(expectedResult, projectOutputToPartial(*actualResult)) {
@@ -526,7 +527,7 @@ func TestXSSLwcDomManualRule(t *testing.T) {
 	createData("./src", security.XSSLwcDomManualRuleID, "")
 
 	//When
-	actualResult, _ := scanner.RunRulesOnFiles(filePaths, ruleInstances)
+	actualResult, _ := scanner.RunRulesOnFiles(context.Background(), filePaths, ruleInstances, nil)
 
 	//then
 	if !reflect.DeepEqual(expectedResult, projectOutputToPartial(*actualResult)) {
@@ -548,7 +549,7 @@ func TestXSSJavascriptButtonRule(t *testing.T) {
 	createData("./src", security.XSSJavascriptButtonRuleID, "")
 
 	//When
-	actualResult, _ := scanner.RunRulesOnFiles(filePaths, ruleInstances)
+	actualResult, _ := scanner.RunRulesOnFiles(context.Background(), filePaths, ruleInstances, nil)
 
 	//thn
 	if !reflect.DeepEqual(expectedResult, projectOutputToPartial(*actualResult)) {
@@ -571,7 +572,7 @@ func TestApexClassWithoutSharingRuleIDRule(t *testing.T) {
 	createData("./src", security.ApexClassWithoutSharingRuleID, "")
 
 	//When
-	actualResult, _ := scanner.RunRulesOnFiles(filePaths, ruleInstances)
+	actualResult, _ := scanner.RunRulesOnFiles(context.Background(), filePaths, ruleInstances, nil)
 
 	//then
 	if !reflect.DeepEqual(expectedResult, projectOutputToPartial(*actualResult)) {
@@ -595,7 +596,7 @@ func TestApexClassNoSharingRule(t *testing.T) {
 	createData("./src", security.ApexClassNoSharingRuleID, "")
 
 	//When
-	actualResult, _ := scanner.RunRulesOnFiles(filePaths, ruleInstances)
+	actualResult, _ := scanner.RunRulesOnFiles(context.Background(), filePaths, ruleInstances, nil)
 
 	//then
 	if !reflect.DeepEqual(expectedResult, projectOutputToPartial(*actualResult)) {
@@ -616,7 +617,7 @@ func TestHardcodedCredentialsRule(t *testing.T) {
 	createData("./src", security.HardcodedCredentialsRuleID, "")
 
 	//When
-	actualResult, _ := scanner.RunRulesOnFiles(filePaths, ruleInstances)
+	actualResult, _ := scanner.RunRulesOnFiles(context.Background(), filePaths, ruleInstances, nil)
 
 	//then
 	if !reflect.DeepEqual(expectedResult, projectOutputToPartial(*actualResult)) {
@@ -638,7 +639,7 @@ func TestInsecureCryptoAlgorithmRule(t *testing.T) {
 	createData("./src", security.InsecureCryptoAlgorithmRuleID, "")
 
 	//When
-	actualResult, _ := scanner.RunRulesOnFiles(filePaths, ruleInstances)
+	actualResult, _ := scanner.RunRulesOnFiles(context.Background(), filePaths, ruleInstances, nil)
 
 	//then
 	if !reflect.DeepEqual(expectedResult, projectOutputToPartial(*actualResult)) {
@@ -661,7 +662,7 @@ func TestDetectMissingAccessibilityModifierRule(t *testing.T) {
 	createData("./src", codequality.DetectMissingAccessibilityModifierRuleID, "")
 
 	//When
-	actualResult, _ := scanner.RunRulesOnFiles(filePaths, ruleInstances)
+	actualResult, _ := scanner.RunRulesOnFiles(context.Background(), filePaths, ruleInstances, nil)
 
 	//then
 	if !reflect.DeepEqual(expectedResult, projectOutputToPartial(*actualResult)) {
@@ -712,7 +713,7 @@ func TestLwcNonStandardPositioningRule(t *testing.T) {
 	}
 
 	// When
-	actualResult, _ := scanner.RunRulesOnFiles(filePaths, ruleInstances)
+	actualResult, _ := scanner.RunRulesOnFiles(context.Background(), filePaths, ruleInstances, nil)
 
 	// Then
 	if !reflect.DeepEqual(expectedResult, projectOutputToPartial(*actualResult)) {
@@ -778,7 +779,7 @@ func TestDetectImportJavascriptFromFileRule(t *testing.T) {
 	}
 
 	//When
-	actualResult, _ := scanner.RunRulesOnFiles(filePaths, ruleInstances)
+	actualResult, _ := scanner.RunRulesOnFiles(context.Background(), filePaths, ruleInstances, nil)
 
 	//Then
 	if !reflect.DeepEqual(expectedResult, projectOutputToPartial(*actualResult)) {
@@ -822,7 +823,7 @@ func TestMaxIssues_WhenWithinThreshold_NoViolation(t *testing.T) {
 	createData("./src", security.ExposedMessageChannelRuleID, "./testData/maxissues_config.yaml")
 
 	//When
-	actualResult, _ := scanner.RunRulesOnFiles(filePaths, ruleInstances)
+	actualResult, _ := scanner.RunRulesOnFiles(context.Background(), filePaths, ruleInstances, nil)
 	actualResult.Count = len(actualResult.Results)
 
 	//Then - should have 1 finding which is within threshold of 5
@@ -843,7 +844,7 @@ func TestMaxIssues_WhenExceedsThreshold_HasViolation(t *testing.T) {
 	createData("./src", security.ProtectedCustomSettingRuleID, "./testData/maxissues_config.yaml")
 
 	//When
-	actualResult, _ := scanner.RunRulesOnFiles(filePaths, ruleInstances)
+	actualResult, _ := scanner.RunRulesOnFiles(context.Background(), filePaths, ruleInstances, nil)
 	actualResult.Count = len(actualResult.Results)
 
 	//Then - should have 2 findings which exceeds threshold of 1
@@ -32,7 +32,7 @@ func createData(path string, standardRuleID rules.RuleID, configPath string) {
 	standardRuleIDs = []rules.RuleID{standardRuleID}
 	customRuleIDs = []rules.RuleID{}
 	configFile, _ = config.ParseConfig(configPath)
-	ruleInstances, _ = ruleset.CreateAndOverrideRules(standardRuleIDs, customRuleIDs, configFile)
+	ruleInstances, _ = ruleset.CreateAndOverrideRules(standardRuleIDs, customRuleIDs, configFile, false)
 }
 
 type PartialFinding struct {
@@ -0,0 +1,89 @@
+package lsp
+
+import (
+	"fmt"
+	"path/filepath"
+	"regexp"
+	"strings"
+)
+
+const (
+	ignoreBeginComment = "asist-ignore-begin"
+	ignoreEndComment   = "asist-ignore-end"
+	sampleDescription  = "TODO Write comment here"
+)
+
+// File extensions which use HTML comments and block comments, the other files use line comments
+var htmlCommentExtensions = map[string]bool{"page": true, "html": true, "htm": true, "component": true, "xml": true}
+var blockCommentExtensions = map[string]bool{"css": true}
+
+var diagnosticRuleIDRegexp = regexp.MustCompile(`^\[([^\]]+)\]`)
+
+/**
+ * createCodeActions - method used to create the "Mark as false positive" quick fix of the ASIST diagnostics of a document
+ */
+func createCodeActions(params codeActionParams, text string) []codeAction {
+	codeActions := []codeAction{}
+	lines := strings.SplitAfter(text, "\n")
+	for _, diag := range params.Context.Diagnostics {
+		if diag.Source != diagnosticSource {
+			continue
+		}
+		ruleIDMatch := diagnosticRuleIDRegexp.FindStringSubmatch(diag.Message)
+		if ruleIDMatch == nil || diag.Range.Start.Line >= len(lines) {
+			continue
+		}
+		codeActions = append(codeActions, codeAction{
+			Title:       markFalsePositiveTitle,
+			Kind:        codeActionKindQuickFix,
+			Diagnostics: []diagnostic{diag},
+			Edit: workspaceEdit{Changes: map[string][]textEdit{
+				params.TextDocument.URI: {createFalsePositiveEdit(params.TextDocument.URI, lines, diag.Range.Start.Line, ruleIDMatch[1])},
+			}},
+		})
+	}
+	return codeActions
+}
+
+/**
+ * createFalsePositiveEdit - method used to add the rule ID to the ignore comment of the previous line if there is one,
+ *	otherwise to surround the line with new asist-ignore-begin and asist-ignore-end comments
+ */
+func createFalsePositiveEdit(uri string, lines []string, line int, ruleID string) textEdit {
+	if line > 0 && strings.Contains(lines[line-1], ignoreBeginComment) {
+		// A false positive exists already
+		commentText := strings.SplitN(lines[line-1], "]", 2)
+		newText := fmt.Sprintf("%s,%s]", commentText[0], ruleID)
+		if len(commentText) > 1 {
+			newText += commentText[1]
+		}
+		return textEdit{Range: getLinesRange(line-1, line), NewText: newText}
+	}
+
+	currentCode := lines[line]
+	if !strings.HasSuffix(currentCode, "\n") {
+		currentCode += "\n"
+	}
+	leadingSpace := currentCode[:len(currentCode)-len(strings.TrimLeft(currentCode, " \t"))]
+	openComment, closeComment := getCommentDelimiters(uri)
+	newText := fmt.Sprintf("%s%s%s:[%s] %s %s%s%s%s%s%s",
+		leadingSpace, openComment, ignoreBeginComment, ruleID, sampleDescription, closeComment,
+		currentCode,
+		leadingSpace, openComment, ignoreEndComment, closeComment)
+	return textEdit{Range: getLinesRange(line, line+1), NewText: newText}
+}
+
+func getCommentDelimiters(uri string) (string, string) {
+	extension := strings.TrimPrefix(filepath.Ext(uri), ".")
+	if htmlCommentExtensions[extension] {
+		return "<!-- ", "-->\n"
+	}
+	if blockCommentExtensions[extension] {
+		return "/*", "*/\n"
+	}
+	return "//", "\n"
+}
+
+func getLinesRange(startLine int, endLine int) textRange {
+	return textRange{Start: position{Line: startLine}, End: position{Line: endLine}}
+}
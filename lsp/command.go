@@ -0,0 +1,21 @@
+package lsp
+
+import (
+	"os"
+
+	"github.com/certinia/asist/errorhandler"
+	"github.com/certinia/asist/message"
+)
+
+// Command is the asist lsp subcommand, it runs the language server over stdio until the client exits
+type Command struct{}
+
+/**
+ * Execute - method used to run the language server on the standard input and output
+ */
+func (c *Command) Execute(args []string) error {
+	if err := NewServer(os.Stdin, os.Stdout).Run(); err != nil {
+		return errorhandler.NewInternalError(message.GetLanguageServerError(err))
+	}
+	return nil
+}
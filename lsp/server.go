@@ -0,0 +1,277 @@
+package lsp
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/url"
+	"path/filepath"
+	"runtime"
+	"strings"
+	"unicode/utf16"
+
+	"github.com/certinia/asist/config"
+	"github.com/certinia/asist/engine"
+	"github.com/certinia/asist/files"
+	"github.com/certinia/asist/finding"
+	"github.com/certinia/asist/parser/options"
+	"github.com/certinia/asist/rules"
+	"github.com/certinia/asist/scanner"
+)
+
+// Server is a language server which scans the documents opened in an editor with the rules loaded once at initialization
+type Server struct {
+	reader     *bufio.Reader
+	writer     io.Writer
+	configFile *config.Config
+	scanEngine *engine.Scanner
+	// documents contains the text of the open documents by URI
+	documents map[string]string
+}
+
+/**
+ * NewServer - method used to create a language server reading requests from reader and writing responses to writer
+ */
+func NewServer(reader io.Reader, writer io.Writer) *Server {
+	return &Server{
+		reader:    bufio.NewReader(reader),
+		writer:    writer,
+		documents: map[string]string{},
+	}
+}
+
+/**
+ * Run - method used to handle the messages of the client until it sends the exit notification or closes the input
+ */
+func (s *Server) Run() error {
+	for {
+		msg, err := readMessage(s.reader)
+		if errors.Is(err, io.EOF) {
+			return nil
+		}
+		if err != nil {
+			return err
+		}
+		if msg.Method == "exit" {
+			return nil
+		}
+		if err := s.handle(msg); err != nil {
+			return err
+		}
+	}
+}
+
+/**
+ * handle - method used to dispatch a request or notification to its handler and reply to requests
+ */
+func (s *Server) handle(msg *rpcMessage) error {
+	var result interface{}
+	var responseErr *responseError
+	switch msg.Method {
+	case "initialize":
+		result, responseErr = s.initialize(msg.Params)
+	case "shutdown", "initialized":
+		// Nothing to do, the documents are dropped when the client exits
+	case "textDocument/didOpen":
+		var params didOpenTextDocumentParams
+		if json.Unmarshal(msg.Params, &params) == nil {
+			s.documents[params.TextDocument.URI] = params.TextDocument.Text
+			return s.publishDiagnostics(params.TextDocument.URI)
+		}
+	case "textDocument/didChange":
+		var params didChangeTextDocumentParams
+		if json.Unmarshal(msg.Params, &params) == nil && len(params.ContentChanges) > 0 {
+			// Documents are synchronized in full, so the last change contains the whole text
+			s.documents[params.TextDocument.URI] = params.ContentChanges[len(params.ContentChanges)-1].Text
+			return s.publishDiagnostics(params.TextDocument.URI)
+		}
+	case "textDocument/didSave":
+		var params didCloseTextDocumentParams
+		if json.Unmarshal(msg.Params, &params) == nil {
+			return s.publishDiagnostics(params.TextDocument.URI)
+		}
+	case "textDocument/didClose":
+		var params didCloseTextDocumentParams
+		if json.Unmarshal(msg.Params, &params) == nil {
+			delete(s.documents, params.TextDocument.URI)
+			return s.sendNotification(publishDiagnosticsMethod, publishDiagnosticsParams{URI: params.TextDocument.URI, Diagnostics: []diagnostic{}})
+		}
+	case "textDocument/codeAction":
+		var params codeActionParams
+		if err := json.Unmarshal(msg.Params, &params); err != nil {
+			responseErr = &responseError{Code: invalidParamsCode, Message: err.Error()}
+		} else {
+			result = createCodeActions(params, s.documents[params.TextDocument.URI])
+		}
+	default:
+		if msg.ID != nil {
+			responseErr = &responseError{Code: methodNotFoundCode, Message: fmt.Sprintf("method not found: %s", msg.Method)}
+		}
+	}
+	// Notifications have no ID and must not be replied to
+	if msg.ID == nil {
+		return nil
+	}
+	if responseErr != nil {
+		return writeMessage(s.writer, errorResponse{JSONRPC: jsonRPCVersion, ID: msg.ID, Error: responseErr})
+	}
+	return writeMessage(s.writer, response{JSONRPC: jsonRPCVersion, ID: msg.ID, Result: result})
+}
+
+/**
+ * initialize - method used to load the config file and the rules, and to return the capabilities of the server
+ */
+func (s *Server) initialize(rawParams json.RawMessage) (interface{}, *responseError) {
+	var params initializeParams
+	if len(rawParams) > 0 {
+		if err := json.Unmarshal(rawParams, &params); err != nil {
+			return nil, &responseError{Code: invalidParamsCode, Message: err.Error()}
+		}
+	}
+	opts := options.GetOptions()
+	configFile, err := config.Load(getConfigFilePath(opts, params))
+	if err != nil {
+		return nil, &responseError{Code: invalidParamsCode, Message: err.Error()}
+	}
+	scanEngine, err := engine.NewScanner(configFile, scanner.NewScanOptions(opts))
+	if err != nil {
+		return nil, &responseError{Code: invalidParamsCode, Message: err.Error()}
+	}
+	s.configFile = configFile
+	s.scanEngine = scanEngine
+
+	return initializeResult{
+		Capabilities: serverCapabilities{
+			TextDocumentSync:   textDocumentSyncOptions{OpenClose: true, Change: textDocumentSyncFull, Save: true},
+			CodeActionProvider: codeActionOptions{CodeActionKinds: []string{codeActionKindQuickFix}},
+		},
+		ServerInfo: serverInfo{Name: diagnosticSource, Version: scanner.Version},
+	}, nil
+}
+
+/**
+ * getConfigFilePath - method used to get the config file given with the -c option, else the one given in the
+ *	initialization options, else the one at the root of the workspace
+ */
+func getConfigFilePath(opts *options.Options, params initializeParams) string {
+	if opts.ConfigFile != "" {
+		return opts.ConfigFile
+	}
+	rootPath := ""
+	if params.RootURI != "" {
+		rootPath = uriToPath(params.RootURI)
+	} else if len(params.WorkspaceFolders) > 0 {
+		rootPath = uriToPath(params.WorkspaceFolders[0].URI)
+	}
+	if configFilePath, ok := params.InitializationOptions[initializationConfigOption].(string); ok && strings.TrimSpace(configFilePath) != "" {
+		if !filepath.IsAbs(configFilePath) && rootPath != "" {
+			configFilePath = filepath.Join(rootPath, configFilePath)
+		}
+		return configFilePath
+	}
+	if rootPath == "" {
+		return ""
+	}
+	return config.FindConfigFile(rootPath)
+}
+
+/**
+ * publishDiagnostics - method used to scan the text of an open document and send its findings to the client
+ */
+func (s *Server) publishDiagnostics(uri string) error {
+	text, isOpen := s.documents[uri]
+	if !isOpen || s.scanEngine == nil {
+		return nil
+	}
+	diagnostics := []diagnostic{}
+	path := uriToPath(uri)
+	if len(s.configFile.FilterExcludedFilesAndFolders([]string{path})) > 0 {
+		fileMaster, err := files.ParseWithOptions(path, strings.NewReader(text), s.scanEngine.ParseOptions())
+		if err != nil {
+			return err
+		}
+		for _, result := range s.scanEngine.ScanFile(context.Background(), fileMaster).Results {
+			diagnostics = append(diagnostics, createDiagnostic(uri, result, fileMaster))
+		}
+	}
+	return s.sendNotification(publishDiagnosticsMethod, publishDiagnosticsParams{URI: uri, Diagnostics: diagnostics})
+}
+
+func (s *Server) sendNotification(method string, params interface{}) error {
+	return writeMessage(s.writer, notification{JSONRPC: jsonRPCVersion, Method: method, Params: params})
+}
+
+/**
+ * createDiagnostic - method used to convert a finding into a diagnostic, like the diagnostics of the VS Code extension
+ */
+func createDiagnostic(uri string, result finding.Finding, fileMaster *files.File) diagnostic {
+	line := result.Occurrence.LineNumber - 1
+	// A long line may be split into several chunks, the column range is relative to the whole source line
+	lineText := fileMaster.GetSourceLine(result.Occurrence.LineNumber)
+	startColumn, endColumn := 0, len(lineText)
+	if len(result.Occurrence.ColumnRange) == 2 {
+		startColumn, endColumn = result.Occurrence.ColumnRange[0], result.Occurrence.ColumnRange[1]
+	}
+	diagnosticRange := textRange{
+		Start: position{Line: line, Character: toUTF16Offset(lineText, startColumn)},
+		End:   position{Line: line, Character: toUTF16Offset(lineText, endColumn)},
+	}
+	return diagnostic{
+		Range:    diagnosticRange,
+		Severity: getDiagnosticSeverity(result.Severity),
+		Code:     string(result.ID),
+		Source:   diagnosticSource,
+		Message:  fmt.Sprintf("[%s] %s", result.ID, result.Name),
+		RelatedInformation: []diagnosticRelatedInformation{{
+			Location: location{URI: uri, Range: diagnosticRange},
+			Message:  result.Description,
+		}},
+	}
+}
+
+func getDiagnosticSeverity(severity rules.Severity) int {
+	switch severity {
+	case rules.SeverityCritical, rules.SeverityHigh:
+		return diagnosticSeverityError
+	case rules.SeverityMedium:
+		return diagnosticSeverityWarning
+	default:
+		return diagnosticSeverityInfo
+	}
+}
+
+/**
+ * toUTF16Offset - method used to convert a byte offset of a line into the UTF-16 offset expected by LSP clients
+ */
+func toUTF16Offset(lineText string, byteOffset int) int {
+	if byteOffset < 0 {
+		return 0
+	}
+	if byteOffset > len(lineText) {
+		byteOffset = len(lineText)
+	}
+	offset := 0
+	for _, character := range lineText[:byteOffset] {
+		offset += utf16.RuneLen(character)
+	}
+	return offset
+}
+
+/**
+ * uriToPath - method used to convert a file URI into a file path
+ */
+func uriToPath(uri string) string {
+	parsedURI, err := url.Parse(uri)
+	if err != nil || parsedURI.Scheme != "file" {
+		return uri
+	}
+	path := parsedURI.Path
+	// Windows URIs look like file:///c:/folder/file.cls
+	if runtime.GOOS == "windows" {
+		path = strings.TrimPrefix(path, "/")
+	}
+	return filepath.FromSlash(path)
+}
@@ -0,0 +1,200 @@
+package lsp
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/json"
+	"errors"
+	"io"
+	"net/textproto"
+	"reflect"
+	"strconv"
+	"strings"
+	"testing"
+
+	"github.com/certinia/asist/files"
+	"github.com/certinia/asist/finding"
+	"github.com/certinia/asist/rules"
+)
+
+/**
+ * runServer - helper used to run the server on the given client messages and return the messages it wrote
+ */
+func runServer(t *testing.T, clientMessages ...interface{}) []map[string]interface{} {
+	var input, output bytes.Buffer
+	for _, msg := range clientMessages {
+		if err := writeMessage(&input, msg); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if err := NewServer(&input, &output).Run(); err != nil {
+		t.Fatalf("Should not return any error! %v", err)
+	}
+	var serverMessages []map[string]interface{}
+	reader := bufio.NewReader(&output)
+	for {
+		headers, err := textproto.NewReader(reader).ReadMIMEHeader()
+		if errors.Is(err, io.EOF) {
+			return serverMessages
+		}
+		if err != nil {
+			t.Fatal(err)
+		}
+		contentLength, _ := strconv.Atoi(headers.Get(contentLengthHeader))
+		content := make([]byte, contentLength)
+		if _, err := io.ReadFull(reader, content); err != nil {
+			t.Fatal(err)
+		}
+		var serverMessage map[string]interface{}
+		if err := json.Unmarshal(content, &serverMessage); err != nil {
+			t.Fatal(err)
+		}
+		serverMessages = append(serverMessages, serverMessage)
+	}
+}
+
+func request(id int, method string, params interface{}) map[string]interface{} {
+	return map[string]interface{}{"jsonrpc": jsonRPCVersion, "id": id, "method": method, "params": params}
+}
+
+func notify(method string, params interface{}) map[string]interface{} {
+	return map[string]interface{}{"jsonrpc": jsonRPCVersion, "method": method, "params": params}
+}
+
+func TestRun_WhenDocumentOpened_PublishesDiagnostics(t *testing.T) {
+	//Given
+	uri := "file:///project/classes/SampleClass.cls"
+	text := "public class SampleClass {\n}\n"
+
+	//When
+	var input, output bytes.Buffer
+	for _, msg := range []interface{}{
+		request(1, "initialize", map[string]interface{}{}),
+		notify("initialized", map[string]interface{}{}),
+		notify("textDocument/didOpen", map[string]interface{}{"textDocument": map[string]interface{}{"uri": uri, "version": 1, "text": text}}),
+		notify("exit", nil),
+	} {
+		_ = writeMessage(&input, msg)
+	}
+	err := NewServer(&input, &output).Run()
+
+	//Then
+	if err != nil {
+		t.Fatalf("Should not return any error! %v", err)
+	}
+	reader := bufio.NewReader(&output)
+	if _, err := readMessage(reader); err != nil {
+		t.Fatalf("Expected initialize response: %v", err)
+	}
+	msg, err := readMessage(reader)
+	if err != nil || msg.Method != publishDiagnosticsMethod {
+		t.Fatalf("Expected diagnostics to be published, got: %+v %v", msg, err)
+	}
+	var params publishDiagnosticsParams
+	_ = json.Unmarshal(msg.Params, &params)
+	if params.URI != uri || len(params.Diagnostics) == 0 {
+		t.Fatalf("Expected diagnostics for %s, got: %+v", uri, params)
+	}
+	var actualDiagnostic *diagnostic
+	for i := range params.Diagnostics {
+		if params.Diagnostics[i].Code == "ApexClassNoSharing" {
+			actualDiagnostic = &params.Diagnostics[i]
+		}
+	}
+	if actualDiagnostic == nil {
+		t.Fatalf("Expected ApexClassNoSharing diagnostic, got: %+v", params.Diagnostics)
+	}
+	if actualDiagnostic.Source != diagnosticSource || actualDiagnostic.Range.Start.Line != 0 || actualDiagnostic.Severity != diagnosticSeverityWarning {
+		t.Errorf("Diagnostic mismatched. Actual: %+v", actualDiagnostic)
+	}
+}
+
+func TestRun_WhenUnknownRequest_RepliesMethodNotFound(t *testing.T) {
+	//When
+	serverMessages := runServer(t,
+		request(1, "workspace/unknown", nil),
+		notify("$/unknownNotification", nil),
+		request(2, "shutdown", nil),
+		notify("exit", nil),
+	)
+
+	//Then
+	if len(serverMessages) != 2 {
+		t.Fatalf("Expected 2 responses, got: %+v", serverMessages)
+	}
+	if errorResponse, ok := serverMessages[0]["error"].(map[string]interface{}); !ok || errorResponse["code"] != float64(methodNotFoundCode) {
+		t.Errorf("Expected method not found error, got: %+v", serverMessages[0])
+	}
+	if _, hasResult := serverMessages[1]["result"]; !hasResult || serverMessages[1]["error"] != nil {
+		t.Errorf("Expected shutdown result, got: %+v", serverMessages[1])
+	}
+}
+
+func TestCreateCodeActions_WhenNoIgnoreComment_SurroundsLineWithIgnoreComments(t *testing.T) {
+	//Given
+	params := codeActionParams{TextDocument: textDocumentIdentifier{URI: "file:///project/pages/Sample.page"}}
+	params.Context.Diagnostics = []diagnostic{
+		{Source: diagnosticSource, Message: "[XSSLabel] XSS Label", Range: textRange{Start: position{Line: 1}}},
+		{Source: "other", Message: "[Other] Other", Range: textRange{Start: position{Line: 1}}},
+	}
+	text := "<apex:page>\n    {!$Label.abc}\n</apex:page>\n"
+
+	//When
+	actualResult := createCodeActions(params, text)
+
+	//Then
+	if len(actualResult) != 1 || actualResult[0].Title != markFalsePositiveTitle {
+		t.Fatalf("Expected a single quick fix, got: %+v", actualResult)
+	}
+	expectedEdit := textEdit{
+		Range:   textRange{Start: position{Line: 1}, End: position{Line: 2}},
+		NewText: "    <!-- asist-ignore-begin:[XSSLabel] TODO Write comment here -->\n    {!$Label.abc}\n    <!-- asist-ignore-end-->\n",
+	}
+	if !reflect.DeepEqual(actualResult[0].Edit.Changes[params.TextDocument.URI], []textEdit{expectedEdit}) {
+		t.Errorf("Edit mismatched. Actual: %+v, Expected: %+v", actualResult[0].Edit.Changes, expectedEdit)
+	}
+}
+
+func TestCreateCodeActions_WhenIgnoreCommentOnPreviousLine_AddsRuleID(t *testing.T) {
+	//Given
+	params := codeActionParams{TextDocument: textDocumentIdentifier{URI: "file:///project/classes/Sample.cls"}}
+	params.Context.Diagnostics = []diagnostic{{Source: diagnosticSource, Message: "[SOQLInjection] SOQL Injection", Range: textRange{Start: position{Line: 1}}}}
+	text := "\t//asist-ignore-begin:[ApexClassNoSharing] reviewed\n\tpublic class Sample {\n"
+
+	//When
+	actualResult := createCodeActions(params, text)
+
+	//Then
+	expectedEdit := textEdit{
+		Range:   textRange{Start: position{Line: 0}, End: position{Line: 1}},
+		NewText: "\t//asist-ignore-begin:[ApexClassNoSharing,SOQLInjection] reviewed\n",
+	}
+	if len(actualResult) != 1 || !reflect.DeepEqual(actualResult[0].Edit.Changes[params.TextDocument.URI], []textEdit{expectedEdit}) {
+		t.Errorf("Edit mismatched. Actual: %+v, Expected: %+v", actualResult, expectedEdit)
+	}
+}
+
+func TestToUTF16Offset(t *testing.T) {
+	lineText := "é😀a"
+	testCases := map[int]int{0: 0, 2: 1, 6: 3, 7: 4, 100: 4}
+	for byteOffset, expected := range testCases {
+		if actual := toUTF16Offset(lineText, byteOffset); actual != expected {
+			t.Errorf("Offset mismatched for %d. Actual: %d, Expected: %d", byteOffset, actual, expected)
+		}
+	}
+}
+
+func TestCreateDiagnostic_WhenLineIsChunked_ReturnsRangeInSourceLine(t *testing.T) {
+	//Given
+	fileMaster, _ := files.ParseWithOptions("/src/bundle.js", strings.NewReader("first\n"+strings.Repeat("é", 10)+"location.search\nlast\n"), files.ParseOptions{MaxLineLength: 16, ChunkLongLines: true})
+	result := finding.Finding{ID: "XSSLocationSearch", Severity: rules.SeverityHigh, Occurrence: rules.Occurrence{FileName: "/src/bundle.js", LineNumber: 2, ColumnRange: []int{20, 35}}}
+
+	//When
+	actualResult := createDiagnostic("file:///src/bundle.js", result, fileMaster)
+
+	//Then
+	expectedRange := textRange{Start: position{Line: 1, Character: 10}, End: position{Line: 1, Character: 25}}
+	if !reflect.DeepEqual(actualResult.Range, expectedRange) {
+		t.Errorf("Range mismatched. Actual: %+v, Expected: %+v", actualResult.Range, expectedRange)
+	}
+}
@@ -2,6 +2,8 @@ package message
 
 import (
 	"fmt"
+	"strings"
+	"time"
 )
 
 const (
@@ -31,6 +33,14 @@ func GetMissingFileOrFolderError() string {
 	return "Specify a file or folder path to scan\n"
 }
 
+func GetMissingStdinFilenameError() string {
+	return "Specify the virtual file name of the content read from the standard input with --stdin-filename\n"
+}
+
+func GetMissingMaxLineLengthError() string {
+	return "Specify the maximum length of a line to split longer lines into chunks with --max-line-length\n"
+}
+
 func GetPathFetchingError(err error) string {
 	return fmt.Sprintf("Error fetching path: %+v\n", err)
 }
@@ -43,6 +53,25 @@ func GetMarshallingOutputError(err error) string {
 	return fmt.Sprintf("Error marshalling output: %+v\n", err)
 }
 
+func GetTextSummary(count int, severityCounts string) string {
+	return fmt.Sprintf("%d finding(s): %s", count, severityCounts)
+}
+
+func GetNoFindingsSummary() string {
+	return "No findings"
+}
+
+func GetOutputFormatMismatchError(formatCount int, outputCount int) string {
+	return fmt.Sprintf("Specify one --format for each --output, got %d format(s) for %d output(s)\n", formatCount, outputCount)
+}
+
+func GetOutputWriteError(path string, err error) string {
+	if path == "" {
+		return fmt.Sprintf("Error writing output: %v", err)
+	}
+	return fmt.Sprintf("Error writing output file %s: %v", path, err)
+}
+
 func GetFileReadError(fileName string, err error) string {
 	return fmt.Sprintf("Error reading file %s: %v", fileName, err.Error())
 }
@@ -74,3 +103,175 @@ func GetThresholdViolation(ruleId string, count int, max int) string {
 func GetThresholdViolationSummary(count int) string {
 	return fmt.Sprintf("%d rule(s) exceeded their cicdmaxissues threshold.", count)
 }
+
+func GetGitRepositoryError(path string, err error) string {
+	return fmt.Sprintf("Error opening git repository for %s: %v", path, err)
+}
+
+func GetGitRevisionError(ref string, err error) string {
+	return fmt.Sprintf("Error resolving git ref %s: %v", ref, err)
+}
+
+func GetBaselineWriteError(path string, err error) string {
+	return fmt.Sprintf("Error writing baseline file %s: %v", path, err)
+}
+
+func GetBaselineSummary(newCount int, fixedCount int) string {
+	return fmt.Sprintf("Baseline: %d new finding(s), %d finding(s) fixed since the baseline was recorded.", newCount, fixedCount)
+}
+
+func GetBaselineFixedFinding(ruleId string, fileName string, lineNumber int) string {
+	return fmt.Sprintf("  Fixed %s in %s:%d", ruleId, fileName, lineNumber)
+}
+
+func GetLanguageServerError(err error) string {
+	return fmt.Sprintf("Error in language server: %v", err)
+}
+
+func GetScanCanceledError(err error) string {
+	return fmt.Sprintf("Scan stopped before all files were scanned: %v", err)
+}
+
+func GetFileTimeoutDiagnostic(ruleId string, timeout time.Duration) string {
+	return fmt.Sprintf("Scan of the file timed out after %s while running rule %s, the remaining rules were not run", timeout, ruleId)
+}
+
+func GetTruncatedLinesDiagnostic(lineCount int, maxLineLength int, firstLineNumber int) string {
+	return fmt.Sprintf("%d line(s) longer than %d bytes were truncated, starting at line %d. Use --chunk-long-lines to scan them entirely", lineCount, maxLineLength, firstLineNumber)
+}
+
+func GetPartialReadDiagnostic(lineCount int, err error) string {
+	return fmt.Sprintf("Only the first %d line(s) were scanned, reading the file failed: %v", lineCount, err)
+}
+
+func GetDiagnosticWarning(fileName string, status string, reason string, msg string) string {
+	return fmt.Sprintf("Warning: %s %s: %s. %s", fileName, status, reason, msg)
+}
+
+func GetDiffSummary(newCount int, fixedCount int, persistingCount int) string {
+	return fmt.Sprintf("Diff: %d new finding(s), %d finding(s) fixed, %d finding(s) persisting.", newCount, fixedCount, persistingCount)
+}
+
+func GetDiffFailOnError(count int, severities string) string {
+	return fmt.Sprintf("%d new finding(s) of severity %s appeared.", count, severities)
+}
+
+func GetDiffInputError(fileName string, err error) string {
+	return fmt.Sprintf("Error reading scan results %s, only the json output of a scan can be compared: %v", fileName, err)
+}
+
+func GetDiffSectionTitle(title string, count int) string {
+	return fmt.Sprintf("%s findings (%d):", title, count)
+}
+
+func GetNoNewFindingsSummary() string {
+	return "No new findings"
+}
+
+func GetCacheWriteWarning(dir string, err error) string {
+	return SetLogType(Warning, fmt.Sprintf("Error writing cache into %s, the next scan will scan all files again: %v", dir, err))
+}
+
+func GetCacheKeyWarning(err error) string {
+	return SetLogType(Warning, fmt.Sprintf("Error creating the cache key, all files are scanned: %v", err))
+}
+
+func GetWatchChangesSummary(changeTime string, addedCount int, resolvedCount int) string {
+	return fmt.Sprintf("[%s] %d finding(s) added, %d finding(s) resolved", changeTime, addedCount, resolvedCount)
+}
+
+func GetWatchStartedInfo(path string) string {
+	return fmt.Sprintf("Watching %s for changes, press Ctrl+C to stop", path)
+}
+
+func GetWatcherError(err error) string {
+	return fmt.Sprintf("Error watching files: %v", err)
+}
+
+func GetListenError(address string, err error) string {
+	return fmt.Sprintf("Error listening on %s: %v", address, err)
+}
+
+func GetServerStartedInfo(address string) string {
+	return fmt.Sprintf("Listening on http://%s, press Ctrl+C to stop", address)
+}
+
+func GetServerError(err error) string {
+	return fmt.Sprintf("Error in HTTP server: %v", err)
+}
+
+func GetInvalidRequestError(err error) string {
+	return fmt.Sprintf("Invalid request body: %v", err)
+}
+
+func GetMissingScanPathError() string {
+	return "Specify the virtual path of the content to scan in Path"
+}
+
+func GetGitIndexError(err error) string {
+	return fmt.Sprintf("Error reading the git index: %v", err)
+}
+
+func GetCommitBlockedError() string {
+	return "Commit blocked by ASIST. Fix the findings or mark the false positives, then stage the changes again."
+}
+
+func GetExistingHookError(hookPath string) string {
+	return fmt.Sprintf("A pre-commit hook which was not installed by ASIST already exists at %s, use --force to overwrite it", hookPath)
+}
+
+func GetHookWriteError(hookPath string, err error) string {
+	return fmt.Sprintf("Error writing pre-commit hook %s: %v", hookPath, err)
+}
+
+func GetHookInstalledInfo(hookPath string) string {
+	return fmt.Sprintf("Installed pre-commit hook %s", hookPath)
+}
+
+func GetGitHistoryError(err error) string {
+	return fmt.Sprintf("Error reading git history: %v", err)
+}
+
+func GetFindingCommitInfo(hash string, author string, email string, date string) string {
+	return fmt.Sprintf("introduced in %s by %s <%s> on %s", hash, author, email, date)
+}
+
+func GetInvalidMaxCommitsError(maxCommits int) string {
+	return fmt.Sprintf("Invalid --max-commits %d, it must be a positive number", maxCommits)
+}
+
+func GetBlameError(path string, err error) string {
+	return fmt.Sprintf("Error computing git blame of %s: %v", path, err)
+}
+
+func GetBlameStdinError() string {
+	return "--blame cannot be used with --stdin, the content read from the standard input has no git history"
+}
+
+func GetSinceStdinError() string {
+	return "--since cannot be used with --stdin, the content read from the standard input has no git history"
+}
+
+func GetPathStdinError(path string) string {
+	return fmt.Sprintf("%s cannot be scanned with --stdin, the content read from the standard input is scanned instead. Use --stdin-filename to name it", path)
+}
+
+func GetMissingBlameError() string {
+	return "--group-by requires --blame"
+}
+
+func GetTextGroupHeader(group string, count int) string {
+	return fmt.Sprintf("== %s: %d finding(s) ==", group, count)
+}
+
+func GetBaselineVersionError(path string, fileVersion int, supportedVersion int) string {
+	return fmt.Sprintf("Unsupported version %d of baseline file %s, only version %d is supported. Write the baseline again with --write-baseline", fileVersion, path, supportedVersion)
+}
+
+func GetTooManyPathsError(paths []string) string {
+	return fmt.Sprintf("Specify a single file or folder path to scan, got %d: %s", len(paths), strings.Join(paths, " "))
+}
+
+func GetSubcommandPathHelp() string {
+	return "Scans the file or folder at Path. A folder named like a command is run as the command, prefix it with ./ to scan it (e.g. asist ./diff)"
+}
@@ -0,0 +1,73 @@
+package output
+
+import (
+	"encoding/xml"
+
+	"github.com/certinia/asist/finding"
+	"github.com/certinia/asist/rules"
+)
+
+const checkstyleVersion = "8.0"
+
+type checkstyleReport struct {
+	XMLName xml.Name         `xml:"checkstyle"`
+	Version string           `xml:"version,attr"`
+	Files   []checkstyleFile `xml:"file"`
+}
+
+type checkstyleFile struct {
+	Name   string            `xml:"name,attr"`
+	Errors []checkstyleError `xml:"error"`
+}
+
+type checkstyleError struct {
+	Line     int    `xml:"line,attr"`
+	Column   int    `xml:"column,attr,omitempty"`
+	Severity string `xml:"severity,attr"`
+	Message  string `xml:"message,attr"`
+	Source   string `xml:"source,attr"`
+}
+
+/**
+ * createCheckstyleOutput - method used to convert the scan result into a Checkstyle XML report, which can be merged with
+ *	the reports of PMD. The files are in the order of the findings.
+ */
+func createCheckstyleOutput(finalResult *finding.Output) checkstyleReport {
+	report := checkstyleReport{Version: checkstyleVersion, Files: []checkstyleFile{}}
+	fileIndexes := map[string]int{}
+	for _, result := range finalResult.Results {
+		fileName := result.Occurrence.FileName
+		fileIndex, isFileAdded := fileIndexes[fileName]
+		if !isFileAdded {
+			fileIndex = len(report.Files)
+			fileIndexes[fileName] = fileIndex
+			report.Files = append(report.Files, checkstyleFile{Name: fileName})
+		}
+		checkstyleResult := checkstyleError{
+			Line:     result.Occurrence.LineNumber,
+			Severity: getCheckstyleSeverity(result.Severity),
+			Message:  result.Name,
+			Source:   "asist." + string(result.ID),
+		}
+		// Checkstyle columns are 1-based, ColumnRange is 0-based
+		if len(result.Occurrence.ColumnRange) == 2 {
+			checkstyleResult.Column = result.Occurrence.ColumnRange[0] + 1
+		}
+		report.Files[fileIndex].Errors = append(report.Files[fileIndex].Errors, checkstyleResult)
+	}
+	return report
+}
+
+/**
+ * getCheckstyleSeverity - method used to map the rule severity to a Checkstyle severity
+ */
+func getCheckstyleSeverity(severity rules.Severity) string {
+	switch severity {
+	case rules.SeverityCritical, rules.SeverityHigh:
+		return "error"
+	case rules.SeverityMedium:
+		return "warning"
+	default:
+		return "info"
+	}
+}
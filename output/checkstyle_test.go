@@ -0,0 +1,38 @@
+package output
+
+import (
+	"encoding/xml"
+	"testing"
+
+	"github.com/certinia/asist/finding"
+	"github.com/certinia/asist/rules"
+)
+
+func TestCreateCheckstyleOutput_WhenFindingsExist_GroupsErrorsByFile(t *testing.T) {
+	//Given
+	finalResult := &finding.Output{
+		Results: []finding.Finding{
+			{ID: "XSSLabel", Name: "XSS Label", Severity: rules.SeverityHigh, Occurrence: rules.Occurrence{FileName: "/src/a.page", LineNumber: 4, ColumnRange: []int{2, 12}}},
+			{ID: "ApexClassNoSharing", Name: "Apex Class No Sharing", Severity: rules.SeverityMedium, Occurrence: rules.Occurrence{FileName: "/src/a.cls", LineNumber: 1}},
+			{ID: "XSSLabel", Name: "XSS Label", Severity: rules.SeverityLow, Occurrence: rules.Occurrence{FileName: "/src/a.page", LineNumber: 9, ColumnRange: []int{0, 5}}},
+		},
+	}
+
+	//When
+	xmlOutput, err := xml.Marshal(createCheckstyleOutput(finalResult))
+
+	//Then
+	if err != nil {
+		t.Fatalf("Should not return error while marshalling: %v", err)
+	}
+	expected := `<checkstyle version="8.0">` +
+		`<file name="/src/a.page">` +
+		`<error line="4" column="3" severity="error" message="XSS Label" source="asist.XSSLabel"></error>` +
+		`<error line="9" column="1" severity="info" message="XSS Label" source="asist.XSSLabel"></error>` +
+		`</file>` +
+		`<file name="/src/a.cls"><error line="1" severity="warning" message="Apex Class No Sharing" source="asist.ApexClassNoSharing"></error></file>` +
+		`</checkstyle>`
+	if string(xmlOutput) != expected {
+		t.Errorf("Checkstyle output mismatched.\n Actual %s\n Expected %s", xmlOutput, expected)
+	}
+}
@@ -0,0 +1,73 @@
+package output
+
+import (
+	"encoding/csv"
+	"io"
+	"slices"
+	"strconv"
+	"time"
+
+	"github.com/certinia/asist/files"
+	"github.com/certinia/asist/finding"
+)
+
+var csvHeader = []string{"Rule ID", "Rule Name", "Severity", "Category", "File", "Line", "Start Column", "End Column", "False Positive"}
+
+// csvCommitHeader are the columns added when findings have the commit which introduced their line
+var csvCommitHeader = []string{"Commit", "Author", "Author Email", "Commit Date"}
+
+/**
+ * writeCSVOutput - method used to write one row per occurrence, e.g. to compile security review evidence in a spreadsheet.
+ *	Columns are 1-based and the end column is inclusive, ColumnRange is 0-based and end exclusive.
+ *	The commit columns are only written when findings have commits, e.g. with --blame.
+ *	Only baseline scans (-b) report the false positive occurrences, the other scans drop them.
+ */
+func writeCSVOutput(w io.Writer, finalResult *finding.Output) error {
+	csvWriter := csv.NewWriter(w)
+	withCommits := slices.ContainsFunc(finalResult.Results, func(result finding.Finding) bool {
+		return result.Commit != nil
+	})
+	header := csvHeader
+	if withCommits {
+		header = append(slices.Clone(csvHeader), csvCommitHeader...)
+	}
+	if err := csvWriter.Write(header); err != nil {
+		return err
+	}
+	for _, result := range finalResult.Results {
+		startColumn, endColumn := "", ""
+		if len(result.Occurrence.ColumnRange) == 2 {
+			startColumn = strconv.Itoa(result.Occurrence.ColumnRange[0] + 1)
+			endColumn = strconv.Itoa(result.Occurrence.ColumnRange[1])
+		}
+		row := []string{
+			string(result.ID),
+			result.Name,
+			string(result.Severity),
+			string(result.RuleCategory),
+			files.GetRepoRelativePath(result.Occurrence.FileName),
+			strconv.Itoa(result.Occurrence.LineNumber),
+			startColumn,
+			endColumn,
+			strconv.FormatBool(result.Occurrence.IsFalsePositive),
+		}
+		if withCommits {
+			row = append(row, createCSVCommitColumns(result.Commit)...)
+		}
+		if err := csvWriter.Write(row); err != nil {
+			return err
+		}
+	}
+	csvWriter.Flush()
+	return csvWriter.Error()
+}
+
+/**
+ * createCSVCommitColumns - method used to get the commit columns of a finding, empty for the findings without commit
+ */
+func createCSVCommitColumns(commit *finding.Commit) []string {
+	if commit == nil {
+		return make([]string, len(csvCommitHeader))
+	}
+	return []string{commit.Hash, commit.Author, commit.Email, commit.Date.Format(time.RFC3339)}
+}
@@ -0,0 +1,67 @@
+package output
+
+import (
+	"bytes"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/certinia/asist/finding"
+	"github.com/certinia/asist/rules"
+)
+
+func TestWriteCSVOutput_WhenFindingsExist_WritesRowPerOccurrence(t *testing.T) {
+	//Given
+	rootPath := t.TempDir()
+	os.MkdirAll(filepath.Join(rootPath, ".git"), 0750)
+	finalResult := &finding.Output{
+		Results: []finding.Finding{
+			{ID: "XSSLabel", Name: "XSS, Label", Severity: rules.SeverityHigh, RuleCategory: rules.CategorySecurity, Occurrence: rules.Occurrence{FileName: filepath.Join(rootPath, "pages", "a.page"), LineNumber: 4, ColumnRange: []int{2, 12}}},
+			{ID: "ApexClassNoSharing", Name: "Apex Class No Sharing", Severity: rules.SeverityMedium, RuleCategory: rules.CategorySecurity, Occurrence: rules.Occurrence{FileName: filepath.Join(rootPath, "a.cls"), LineNumber: 1, IsFalsePositive: true}},
+		},
+	}
+	var buf bytes.Buffer
+
+	//When
+	err := writeCSVOutput(&buf, finalResult)
+
+	//Then
+	if err != nil {
+		t.Fatalf("Should not return error while writing: %v", err)
+	}
+	expected := "Rule ID,Rule Name,Severity,Category,File,Line,Start Column,End Column,False Positive\n" +
+		"XSSLabel,\"XSS, Label\",High,Security,pages/a.page,4,3,12,false\n" +
+		"ApexClassNoSharing,Apex Class No Sharing,Medium,Security,a.cls,1,,,true\n"
+	if buf.String() != expected {
+		t.Errorf("CSV output mismatched.\n Actual %q\n Expected %q", buf.String(), expected)
+	}
+}
+
+func TestWriteCSVOutput_WhenFindingsHaveCommits_WritesCommitColumns(t *testing.T) {
+	//Given
+	rootPath := t.TempDir()
+	os.MkdirAll(filepath.Join(rootPath, ".git"), 0750)
+	commit := &finding.Commit{Hash: "0123456789", Author: "Jane Doe", Email: "jane@example.com", Date: time.Date(2024, 5, 1, 10, 0, 0, 0, time.UTC)}
+	finalResult := &finding.Output{
+		Results: []finding.Finding{
+			{ID: "ApexClassNoSharing", Name: "Apex Class No Sharing", Severity: rules.SeverityMedium, RuleCategory: rules.CategorySecurity, Occurrence: rules.Occurrence{FileName: filepath.Join(rootPath, "a.cls"), LineNumber: 1}, Commit: commit},
+			{ID: "ApexClassNoSharing", Name: "Apex Class No Sharing", Severity: rules.SeverityMedium, RuleCategory: rules.CategorySecurity, Occurrence: rules.Occurrence{FileName: filepath.Join(rootPath, "b.cls"), LineNumber: 1}},
+		},
+	}
+	var buf bytes.Buffer
+
+	//When
+	err := writeCSVOutput(&buf, finalResult)
+
+	//Then
+	if err != nil {
+		t.Fatalf("Should not return error while writing: %v", err)
+	}
+	expected := "Rule ID,Rule Name,Severity,Category,File,Line,Start Column,End Column,False Positive,Commit,Author,Author Email,Commit Date\n" +
+		"ApexClassNoSharing,Apex Class No Sharing,Medium,Security,a.cls,1,,,false,0123456789,Jane Doe,jane@example.com,2024-05-01T10:00:00Z\n" +
+		"ApexClassNoSharing,Apex Class No Sharing,Medium,Security,b.cls,1,,,false,,,,\n"
+	if buf.String() != expected {
+		t.Errorf("CSV output mismatched.\n Actual %q\n Expected %q", buf.String(), expected)
+	}
+}
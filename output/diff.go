@@ -0,0 +1,106 @@
+package output
+
+import (
+	"fmt"
+	"io"
+	"os"
+
+	"github.com/certinia/asist/finding"
+	"github.com/certinia/asist/message"
+	"github.com/certinia/asist/parser/options"
+)
+
+// SARIF baseline states of the new, persisting and fixed findings
+const (
+	sarifBaselineStateNew       = "new"
+	sarifBaselineStateUnchanged = "unchanged"
+	sarifBaselineStateAbsent    = "absent"
+)
+
+/**
+ * DisplayDiffOutput - method used to write the new, fixed and persisting findings between two scans by type.
+ *	The formats which cannot tell the sets apart only get the new findings, e.g. to annotate them in a pull request.
+ */
+func DisplayDiffOutput(diffOutput *finding.DiffOutput) {
+	fmt.Fprintf(os.Stderr, "%s\n", message.GetDiffSummary(len(diffOutput.New), len(diffOutput.Fixed), len(diffOutput.Persisting)))
+	for _, outputTarget := range options.GetOutputTargets() {
+		writeDiffOutput(outputTarget, diffOutput)
+	}
+}
+
+/**
+ * writeDiffOutput - method used to write the diff of two scans in the format of the output target
+ */
+func writeDiffOutput(outputTarget options.OutputTarget, diffOutput *finding.DiffOutput) {
+	switch outputTarget.Format {
+	case options.FormatJSON, options.FormatSarif, options.FormatText, options.FormatMarkdown:
+	default:
+		writeOutput(outputTarget, &finding.Output{Count: len(diffOutput.New), Results: diffOutput.New})
+		return
+	}
+	w, closeOutput := openOutput(outputTarget.Path)
+	switch outputTarget.Format {
+	case options.FormatSarif:
+		displayOutput(w, createSarifDiffOutput(diffOutput))
+	case options.FormatText:
+		writeTextDiffOutput(w, diffOutput, isColorTerminal(w))
+	case options.FormatMarkdown:
+		writeMarkdownDiffOutput(w, diffOutput)
+	default:
+		displayOutput(w, diffOutput)
+	}
+	closeOutput()
+}
+
+/**
+ * createSarifDiffOutput - method used to convert the diff of two scans into a SARIF log, the set of each result is its baseline state
+ */
+func createSarifDiffOutput(diffOutput *finding.DiffOutput) sarifLog {
+	results := append(append(append([]finding.Finding{}, diffOutput.New...), diffOutput.Persisting...), diffOutput.Fixed...)
+	sarifOutput := createSarifOutput(&finding.Output{Count: len(results), Results: results})
+	for index := range sarifOutput.Runs[0].Results {
+		switch {
+		case index < len(diffOutput.New):
+			sarifOutput.Runs[0].Results[index].BaselineState = sarifBaselineStateNew
+		case index < len(diffOutput.New)+len(diffOutput.Persisting):
+			sarifOutput.Runs[0].Results[index].BaselineState = sarifBaselineStateUnchanged
+		default:
+			sarifOutput.Runs[0].Results[index].BaselineState = sarifBaselineStateAbsent
+		}
+	}
+	return sarifOutput
+}
+
+/**
+ * writeTextDiffOutput - method used to write the new, fixed and persisting findings for a human reader, one section per set
+ */
+func writeTextDiffOutput(w io.Writer, diffOutput *finding.DiffOutput, useColors bool) {
+	sections := []struct {
+		title    string
+		findings []finding.Finding
+	}{
+		{"New", diffOutput.New},
+		{"Fixed", diffOutput.Fixed},
+		{"Persisting", diffOutput.Persisting},
+	}
+	for index, section := range sections {
+		if index > 0 {
+			fmt.Fprintln(w)
+		}
+		fmt.Fprintf(w, "%s\n", message.GetDiffSectionTitle(section.title, len(section.findings)))
+		writeTextOutput(w, &finding.Output{Count: len(section.findings), Results: section.findings}, useColors, "")
+	}
+}
+
+/**
+ * writeMarkdownDiffOutput - method used to write the number of new, fixed and persisting findings followed by the tables of the new findings
+ */
+func writeMarkdownDiffOutput(w io.Writer, diffOutput *finding.DiffOutput) {
+	fmt.Fprintf(w, "## %s diff\n\n", toolName)
+	fmt.Fprintf(w, "| New | Fixed | Persisting |\n| ---: | ---: | ---: |\n| %d | %d | %d |\n\n", len(diffOutput.New), len(diffOutput.Fixed), len(diffOutput.Persisting))
+	if len(diffOutput.New) == 0 {
+		fmt.Fprintf(w, "%s\n", message.GetNoNewFindingsSummary())
+		return
+	}
+	writeMarkdownTables(w, diffOutput.New, "New findings")
+}
@@ -0,0 +1,37 @@
+package output
+
+import (
+	"testing"
+
+	"github.com/certinia/asist/finding"
+	"github.com/certinia/asist/rules"
+)
+
+func TestCreateSarifDiffOutput_WhenFindingsChanged_SetsBaselineStates(t *testing.T) {
+	//Given
+	diffOutput := &finding.DiffOutput{
+		New:        []finding.Finding{{ID: "XSSLabel", Severity: rules.SeverityHigh, Occurrence: rules.Occurrence{FileName: "/src/a.page", LineNumber: 1}}},
+		Fixed:      []finding.Finding{{ID: "XSSLabel", Severity: rules.SeverityHigh, Occurrence: rules.Occurrence{FileName: "/src/b.page", LineNumber: 2}}},
+		Persisting: []finding.Finding{{ID: "SessionIDApex", Severity: rules.SeverityMedium, Occurrence: rules.Occurrence{FileName: "/src/a.cls", LineNumber: 3}}},
+	}
+
+	//When
+	sarifOutput := createSarifDiffOutput(diffOutput)
+
+	//Then
+	expected := map[string]string{
+		"file:///src/a.page": sarifBaselineStateNew,
+		"file:///src/a.cls":  sarifBaselineStateUnchanged,
+		"file:///src/b.page": sarifBaselineStateAbsent,
+	}
+	results := sarifOutput.Runs[0].Results
+	if len(results) != len(expected) {
+		t.Fatalf("Expected %d results, got %d", len(expected), len(results))
+	}
+	for _, result := range results {
+		uri := result.Locations[0].PhysicalLocation.ArtifactLocation.URI
+		if result.BaselineState != expected[uri] {
+			t.Errorf("Baseline state of %s mismatched. Actual %s Expected %s", uri, result.BaselineState, expected[uri])
+		}
+	}
+}
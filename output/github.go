@@ -0,0 +1,60 @@
+package output
+
+import (
+	"fmt"
+	"io"
+	"strings"
+
+	"github.com/certinia/asist/files"
+	"github.com/certinia/asist/finding"
+	"github.com/certinia/asist/rules"
+)
+
+// Escaping of the data and of the property values of GitHub Actions workflow commands
+var githubDataEscaper = strings.NewReplacer("%", "%25", "\r", "%0D", "\n", "%0A")
+var githubPropertyEscaper = strings.NewReplacer("%", "%25", "\r", "%0D", "\n", "%0A", ":", "%3A", ",", "%2C")
+
+/**
+ * writeGithubOutput - method used to write the findings as GitHub Actions workflow commands, shown as annotations of pull requests
+ */
+func writeGithubOutput(w io.Writer, finalResult *finding.Output) {
+	for _, result := range finalResult.Results {
+		fmt.Fprintln(w, createGithubAnnotation(result))
+	}
+}
+
+/**
+ * createGithubAnnotation - method used to convert a finding into an error, warning or notice workflow command.
+ *	Annotation columns are 1-based and the end column is inclusive, ColumnRange is 0-based and end exclusive.
+ */
+func createGithubAnnotation(result finding.Finding) string {
+	properties := []string{
+		"file=" + githubPropertyEscaper.Replace(files.GetRepoRelativePath(result.Occurrence.FileName)),
+		fmt.Sprintf("line=%d", result.Occurrence.LineNumber),
+	}
+	if len(result.Occurrence.ColumnRange) == 2 {
+		startColumn := result.Occurrence.ColumnRange[0] + 1
+		// Zero-width matches are annotated on the start column
+		endColumn := max(result.Occurrence.ColumnRange[1], startColumn)
+		properties = append(properties,
+			fmt.Sprintf("col=%d", startColumn),
+			fmt.Sprintf("endColumn=%d", endColumn),
+		)
+	}
+	properties = append(properties, "title="+githubPropertyEscaper.Replace(string(result.ID)))
+	return fmt.Sprintf("::%s %s::%s", getGithubCommand(result.Severity), strings.Join(properties, ","), githubDataEscaper.Replace(result.Description))
+}
+
+/**
+ * getGithubCommand - method used to map the rule severity to a GitHub Actions annotation command
+ */
+func getGithubCommand(severity rules.Severity) string {
+	switch severity {
+	case rules.SeverityCritical, rules.SeverityHigh:
+		return "error"
+	case rules.SeverityMedium:
+		return "warning"
+	default:
+		return "notice"
+	}
+}
@@ -0,0 +1,50 @@
+package output
+
+import (
+	"bytes"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/certinia/asist/finding"
+	"github.com/certinia/asist/rules"
+)
+
+func TestWriteGithubOutput_WhenFindingsExist_WritesAnnotationPerFinding(t *testing.T) {
+	//Given
+	rootPath := t.TempDir()
+	os.MkdirAll(filepath.Join(rootPath, ".git"), 0750)
+	finalResult := &finding.Output{
+		Results: []finding.Finding{
+			{ID: "XSSLabel", Description: "Label, 100% unescaped:\nfix it", Severity: rules.SeverityHigh, Occurrence: rules.Occurrence{FileName: filepath.Join(rootPath, "a,b.page"), LineNumber: 4, ColumnRange: []int{2, 12}}},
+			{ID: "ApexClassNoSharing", Description: "No sharing", Severity: rules.SeverityMedium, Occurrence: rules.Occurrence{FileName: filepath.Join(rootPath, "a.cls"), LineNumber: 1}},
+			{ID: "SensitiveInfoInDebug", Description: "Debug", Severity: rules.SeverityLow, Occurrence: rules.Occurrence{FileName: filepath.Join(rootPath, "b.cls"), LineNumber: 7}},
+		},
+	}
+	var buf bytes.Buffer
+
+	//When
+	writeGithubOutput(&buf, finalResult)
+
+	//Then
+	expected := "::error file=a%2Cb.page,line=4,col=3,endColumn=12,title=XSSLabel::Label, 100%25 unescaped:%0Afix it\n" +
+		"::warning file=a.cls,line=1,title=ApexClassNoSharing::No sharing\n" +
+		"::notice file=b.cls,line=7,title=SensitiveInfoInDebug::Debug\n"
+	if buf.String() != expected {
+		t.Errorf("GitHub output mismatched.\n Actual %q\n Expected %q", buf.String(), expected)
+	}
+}
+
+func TestCreateGithubAnnotation_WhenMatchIsZeroWidth_ClampsEndColumnToStartColumn(t *testing.T) {
+	//Given
+	result := finding.Finding{ID: "XSSLabel", Description: "Label", Severity: rules.SeverityHigh, Occurrence: rules.Occurrence{FileName: "/src/a.page", LineNumber: 2, ColumnRange: []int{5, 5}}}
+
+	//When
+	annotation := createGithubAnnotation(result)
+
+	//Then
+	if !strings.Contains(annotation, "col=6,endColumn=6,") {
+		t.Errorf("Expected the end column to be clamped to the start column. Actual %q", annotation)
+	}
+}
@@ -0,0 +1,228 @@
+package output
+
+import (
+	"runtime/debug"
+	"strings"
+	"time"
+
+	"github.com/certinia/asist/files"
+	"github.com/certinia/asist/finding"
+	"github.com/certinia/asist/rules"
+)
+
+const (
+	gitlabSastSchemaVersion = "15.0.7"
+	gitlabTimeLayout        = "2006-01-02T15:04:05"
+	// scanTimeLayout is the layout of the scan times of the output, created with time.Time.String
+	scanTimeLayout = "2006-01-02 15:04:05.999999999 -0700 MST"
+)
+
+// ToolVersion is the version of ASIST reported by the outputs which need it, e.g. the GitLab SAST report
+var ToolVersion = ""
+
+type gitlabSastReport struct {
+	Version         string                `json:"version"`
+	Scan            gitlabScan            `json:"scan"`
+	Vulnerabilities []gitlabVulnerability `json:"vulnerabilities"`
+}
+
+type gitlabScan struct {
+	Analyzer  gitlabScanTool `json:"analyzer"`
+	Scanner   gitlabScanTool `json:"scanner"`
+	Type      string         `json:"type"`
+	StartTime string         `json:"start_time"`
+	EndTime   string         `json:"end_time"`
+	Status    string         `json:"status"`
+}
+
+type gitlabScanTool struct {
+	ID      string       `json:"id"`
+	Name    string       `json:"name"`
+	URL     string       `json:"url"`
+	Version string       `json:"version"`
+	Vendor  gitlabVendor `json:"vendor"`
+}
+
+type gitlabVendor struct {
+	Name string `json:"name"`
+}
+
+type gitlabVulnerability struct {
+	ID          string             `json:"id"`
+	Name        string             `json:"name"`
+	Description string             `json:"description"`
+	Severity    string             `json:"severity"`
+	Identifiers []gitlabIdentifier `json:"identifiers"`
+	Location    gitlabLocation     `json:"location"`
+}
+
+type gitlabIdentifier struct {
+	Type  string `json:"type"`
+	Name  string `json:"name"`
+	Value string `json:"value"`
+}
+
+type gitlabLocation struct {
+	File      string `json:"file"`
+	StartLine int    `json:"start_line"`
+	EndLine   int    `json:"end_line"`
+}
+
+type codeClimateIssue struct {
+	Type        string              `json:"type"`
+	CheckName   string              `json:"check_name"`
+	Description string              `json:"description"`
+	Categories  []string            `json:"categories"`
+	Severity    string              `json:"severity"`
+	Fingerprint string              `json:"fingerprint"`
+	Location    codeClimateLocation `json:"location"`
+}
+
+type codeClimateLocation struct {
+	Path  string           `json:"path"`
+	Lines codeClimateLines `json:"lines"`
+}
+
+type codeClimateLines struct {
+	Begin int `json:"begin"`
+	End   int `json:"end"`
+}
+
+/**
+ * createGitlabSastOutput - method used to convert the scan result into a GitLab SAST report, shown in the security widget of merge requests
+ */
+func createGitlabSastOutput(finalResult *finding.Output) gitlabSastReport {
+	scanTool := gitlabScanTool{
+		ID:      strings.ToLower(toolName),
+		Name:    toolName,
+		URL:     toolURI,
+		Version: getToolVersion(),
+		Vendor:  gitlabVendor{Name: "Certinia"},
+	}
+	vulnerabilities := []gitlabVulnerability{}
+	for _, result := range finalResult.Results {
+		vulnerabilities = append(vulnerabilities, gitlabVulnerability{
+			ID:          result.GetFingerprint(),
+			Name:        result.Name,
+			Description: result.Description,
+			Severity:    getGitlabSeverity(result.Severity),
+			Identifiers: []gitlabIdentifier{{
+				Type:  "asist_rule_id",
+				Name:  "ASIST " + string(result.ID),
+				Value: string(result.ID),
+			}},
+			Location: gitlabLocation{
+				File:      files.GetRepoRelativePath(result.Occurrence.FileName),
+				StartLine: result.Occurrence.LineNumber,
+				EndLine:   result.Occurrence.LineNumber,
+			},
+		})
+	}
+	return gitlabSastReport{
+		Version: gitlabSastSchemaVersion,
+		Scan: gitlabScan{
+			Analyzer:  scanTool,
+			Scanner:   scanTool,
+			Type:      "sast",
+			StartTime: getGitlabTime(finalResult.ScanStartedTime),
+			EndTime:   getGitlabTime(finalResult.ScanEndingTime),
+			Status:    "success",
+		},
+		Vulnerabilities: vulnerabilities,
+	}
+}
+
+/**
+ * createCodeClimateOutput - method used to convert the scan result into a Code Climate report, e.g. for the code quality widget of GitLab
+ */
+func createCodeClimateOutput(finalResult *finding.Output) []codeClimateIssue {
+	issues := []codeClimateIssue{}
+	for _, result := range finalResult.Results {
+		issues = append(issues, codeClimateIssue{
+			Type:        "issue",
+			CheckName:   string(result.ID),
+			Description: result.Name,
+			Categories:  []string{getCodeClimateCategory(result.RuleCategory)},
+			Severity:    getCodeClimateSeverity(result.Severity),
+			Fingerprint: result.GetFingerprint(),
+			Location: codeClimateLocation{
+				Path:  files.GetRepoRelativePath(result.Occurrence.FileName),
+				Lines: codeClimateLines{Begin: result.Occurrence.LineNumber, End: result.Occurrence.LineNumber},
+			},
+		})
+	}
+	return issues
+}
+
+/**
+ * getGitlabSeverity - method used to map the rule severity to a GitLab vulnerability severity
+ */
+func getGitlabSeverity(severity rules.Severity) string {
+	switch severity {
+	case rules.SeverityCritical, rules.SeverityHigh, rules.SeverityMedium, rules.SeverityLow:
+		return string(severity)
+	default:
+		return "Unknown"
+	}
+}
+
+/**
+ * getCodeClimateSeverity - method used to map the rule severity to a Code Climate issue severity
+ */
+func getCodeClimateSeverity(severity rules.Severity) string {
+	switch severity {
+	case rules.SeverityCritical:
+		return "blocker"
+	case rules.SeverityHigh:
+		return "critical"
+	case rules.SeverityMedium:
+		return "major"
+	case rules.SeverityLow:
+		return "minor"
+	default:
+		return "info"
+	}
+}
+
+/**
+ * getCodeClimateCategory - method used to map the rule category to a Code Climate issue category
+ */
+func getCodeClimateCategory(category rules.RuleCategory) string {
+	switch category {
+	case rules.CategorySecurity:
+		return "Security"
+	case rules.CategoryPerformance:
+		return "Performance"
+	case rules.CategoryCodeQuality:
+		return "Clarity"
+	default:
+		return "Bug Risk"
+	}
+}
+
+/**
+ * getGitlabTime - method used to convert a scan time of the output into the time format of GitLab reports.
+ *	The current time is used when the scan time is not set.
+ */
+func getGitlabTime(scanTime string) string {
+	// Drop the monotonic clock reading added by time.Time.String
+	scanTime, _, _ = strings.Cut(scanTime, " m=")
+	parsedTime, err := time.Parse(scanTimeLayout, scanTime)
+	if err != nil {
+		parsedTime = time.Now()
+	}
+	return parsedTime.Format(gitlabTimeLayout)
+}
+
+/**
+ * getToolVersion - method used to get the version of ASIST, from the build information when it is not set
+ */
+func getToolVersion() string {
+	if ToolVersion != "" {
+		return ToolVersion
+	}
+	if buildInfo, ok := debug.ReadBuildInfo(); ok && buildInfo.Main.Version != "" {
+		return buildInfo.Main.Version
+	}
+	return "unknown"
+}
@@ -0,0 +1,70 @@
+package output
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/certinia/asist/finding"
+	"github.com/certinia/asist/rules"
+)
+
+func createRepositoryFinding(t *testing.T) finding.Finding {
+	rootPath := t.TempDir()
+	os.MkdirAll(filepath.Join(rootPath, ".git"), 0750)
+	return finding.Finding{
+		ID:           "XSSLabel",
+		Name:         "XSS Label",
+		Description:  "Label description",
+		Severity:     rules.SeverityHigh,
+		RuleCategory: rules.CategorySecurity,
+		Fingerprint:  "fingerprint",
+		Occurrence:   rules.Occurrence{FileName: filepath.Join(rootPath, "force-app", "pages", "a.page"), LineNumber: 4},
+	}
+}
+
+func TestCreateGitlabSastOutput_WhenFindingsExist_ReturnsVulnerabilitiesWithRelativePaths(t *testing.T) {
+	//Given
+	finalResult := &finding.Output{
+		ScanStartedTime: "2026-07-23 13:12:08.231779 +0200 CEST m=+0.000501126",
+		Results:         []finding.Finding{createRepositoryFinding(t)},
+	}
+
+	//When
+	actualResult := createGitlabSastOutput(finalResult)
+
+	//Then
+	if actualResult.Scan.Type != "sast" || actualResult.Scan.StartTime != "2026-07-23T13:12:08" {
+		t.Errorf("Scan mismatched. Actual: %+v", actualResult.Scan)
+	}
+	if len(actualResult.Vulnerabilities) != 1 {
+		t.Fatalf("Expected a single vulnerability. Actual: %+v", actualResult.Vulnerabilities)
+	}
+	vulnerability := actualResult.Vulnerabilities[0]
+	if vulnerability.ID != "fingerprint" || vulnerability.Severity != "High" || vulnerability.Identifiers[0].Value != "XSSLabel" {
+		t.Errorf("Vulnerability mismatched. Actual: %+v", vulnerability)
+	}
+	if vulnerability.Location != (gitlabLocation{File: "force-app/pages/a.page", StartLine: 4, EndLine: 4}) {
+		t.Errorf("Location should be relative to the repository. Actual: %+v", vulnerability.Location)
+	}
+}
+
+func TestCreateCodeClimateOutput_WhenFindingsExist_ReturnsIssuesWithMappedSeverity(t *testing.T) {
+	//Given
+	finalResult := &finding.Output{Results: []finding.Finding{createRepositoryFinding(t)}}
+
+	//When
+	actualResult := createCodeClimateOutput(finalResult)
+
+	//Then
+	if len(actualResult) != 1 {
+		t.Fatalf("Expected a single issue. Actual: %+v", actualResult)
+	}
+	issue := actualResult[0]
+	if issue.CheckName != "XSSLabel" || issue.Severity != "critical" || issue.Categories[0] != "Security" || issue.Fingerprint != "fingerprint" {
+		t.Errorf("Issue mismatched. Actual: %+v", issue)
+	}
+	if issue.Location.Path != "force-app/pages/a.page" || issue.Location.Lines.Begin != 4 {
+		t.Errorf("Location should be relative to the repository. Actual: %+v", issue.Location)
+	}
+}
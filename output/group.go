@@ -0,0 +1,88 @@
+package output
+
+import (
+	"fmt"
+	"sort"
+	"time"
+
+	"github.com/certinia/asist/finding"
+	"github.com/certinia/asist/parser/options"
+)
+
+// uncommittedGroup is the group of the findings without commit, e.g. on lines which are not committed yet
+const uncommittedGroup = "Not committed"
+
+// ageGroup is a group of the findings whose commit is at most maxAge old
+type ageGroup struct {
+	name   string
+	maxAge time.Duration
+}
+
+// ageGroups are sorted from the newest findings, the findings older than the last group are in olderGroup
+var ageGroups = []ageGroup{
+	{name: "Last 7 days", maxAge: 7 * 24 * time.Hour},
+	{name: "Last 30 days", maxAge: 30 * 24 * time.Hour},
+	{name: "Last 90 days", maxAge: 90 * 24 * time.Hour},
+	{name: "Last year", maxAge: 365 * 24 * time.Hour},
+}
+
+const olderGroup = "Older than a year"
+
+/**
+ * groupFindings - method used to group the findings by the author or the age of their commit.
+ *	Authors are sorted by number of findings then by name, ages from the newest. The uncommitted findings are grouped last.
+ */
+func groupFindings(results []finding.Finding, groupBy string, now time.Time) ([]string, map[string][]finding.Finding) {
+	findingsPerGroup := map[string][]finding.Finding{}
+	for _, result := range results {
+		group := getFindingGroup(result, groupBy, now)
+		findingsPerGroup[group] = append(findingsPerGroup[group], result)
+	}
+
+	groups := []string{}
+	if groupBy == options.GroupByAge {
+		for _, group := range ageGroups {
+			if len(findingsPerGroup[group.name]) > 0 {
+				groups = append(groups, group.name)
+			}
+		}
+		if len(findingsPerGroup[olderGroup]) > 0 {
+			groups = append(groups, olderGroup)
+		}
+	} else {
+		for group := range findingsPerGroup {
+			if group != uncommittedGroup {
+				groups = append(groups, group)
+			}
+		}
+		sort.Slice(groups, func(i, j int) bool {
+			if len(findingsPerGroup[groups[i]]) != len(findingsPerGroup[groups[j]]) {
+				return len(findingsPerGroup[groups[i]]) > len(findingsPerGroup[groups[j]])
+			}
+			return groups[i] < groups[j]
+		})
+	}
+	if len(findingsPerGroup[uncommittedGroup]) > 0 {
+		groups = append(groups, uncommittedGroup)
+	}
+	return groups, findingsPerGroup
+}
+
+/**
+ * getFindingGroup - method used to get the author or the age group of the commit of a finding
+ */
+func getFindingGroup(result finding.Finding, groupBy string, now time.Time) string {
+	if result.Commit == nil {
+		return uncommittedGroup
+	}
+	if groupBy != options.GroupByAge {
+		return fmt.Sprintf("%s <%s>", result.Commit.Author, result.Commit.Email)
+	}
+	age := now.Sub(result.Commit.Date)
+	for _, group := range ageGroups {
+		if age <= group.maxAge {
+			return group.name
+		}
+	}
+	return olderGroup
+}
@@ -0,0 +1,49 @@
+package output
+
+import (
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/certinia/asist/finding"
+	"github.com/certinia/asist/parser/options"
+)
+
+func TestGroupFindings_WhenGroupedByAge_ReturnsNewestGroupsFirstAndUncommittedLast(t *testing.T) {
+	//Given
+	now := time.Date(2024, 6, 1, 0, 0, 0, 0, time.UTC)
+	results := []finding.Finding{
+		{ID: "old", Commit: &finding.Commit{Date: now.AddDate(-2, 0, 0)}},
+		{ID: "uncommitted"},
+		{ID: "recent", Commit: &finding.Commit{Date: now.AddDate(0, 0, -1)}},
+		{ID: "lastMonth", Commit: &finding.Commit{Date: now.AddDate(0, 0, -20)}},
+	}
+
+	//When
+	groups, findingsPerGroup := groupFindings(results, options.GroupByAge, now)
+
+	//Then
+	expectedGroups := []string{"Last 7 days", "Last 30 days", "Older than a year", "Not committed"}
+	if !reflect.DeepEqual(groups, expectedGroups) {
+		t.Errorf("Groups mismatched.\n Actual %v\n Expected %v", groups, expectedGroups)
+	}
+	if findingsPerGroup["Last 30 days"][0].ID != "lastMonth" {
+		t.Errorf("Expected the finding of last month in the last 30 days. Actual %+v", findingsPerGroup)
+	}
+}
+
+func TestGroupFindings_WhenGroupedByAuthor_SortsAuthorsByFindingCount(t *testing.T) {
+	//Given
+	jane := &finding.Commit{Author: "Jane", Email: "jane@example.com"}
+	bob := &finding.Commit{Author: "Bob", Email: "bob@example.com"}
+	results := []finding.Finding{{Commit: bob}, {}, {Commit: jane}, {Commit: jane}}
+
+	//When
+	groups, _ := groupFindings(results, options.GroupByAuthor, time.Now())
+
+	//Then
+	expectedGroups := []string{"Jane <jane@example.com>", "Bob <bob@example.com>", "Not committed"}
+	if !reflect.DeepEqual(groups, expectedGroups) {
+		t.Errorf("Groups mismatched.\n Actual %v\n Expected %v", groups, expectedGroups)
+	}
+}
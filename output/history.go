@@ -0,0 +1,19 @@
+package output
+
+import (
+	"os"
+
+	"github.com/certinia/asist/finding"
+	"github.com/certinia/asist/parser/options"
+)
+
+/**
+ * DisplayHistoryOutput - method used to write the findings of a scan of the git history by type.
+ *	The json, sarif and text formats show the commit which introduced each finding.
+ */
+func DisplayHistoryOutput(finalResult *finding.Output) {
+	ReportDiagnostics(os.Stderr, finalResult.Diagnostics)
+	for _, outputTarget := range options.GetOutputTargets() {
+		writeOutput(outputTarget, finalResult)
+	}
+}
@@ -0,0 +1,227 @@
+package output
+
+import (
+	_ "embed"
+	"html/template"
+	"io"
+	"sort"
+
+	"github.com/certinia/asist/finding"
+	"github.com/certinia/asist/rules"
+)
+
+//go:embed templates/report.html
+var htmlReportTemplate string
+
+// severityOrder is the order of the severities in the HTML and text reports, from the most to the least severe
+var severityOrder = []rules.Severity{rules.SeverityCritical, rules.SeverityHigh, rules.SeverityMedium, rules.SeverityLow}
+
+type htmlReport struct {
+	ToolName        string
+	ScanStartedTime string
+	ScanEndingTime  string
+	Count           int
+	SeverityCounts  []htmlCount
+	CategoryCounts  []htmlCount
+	Severities      []htmlSeverityGroup
+	Diagnostics     []finding.Diagnostic
+}
+
+// htmlCount is a bar of the summary charts, Percent is relative to the largest bar of the chart
+type htmlCount struct {
+	Name    string
+	Count   int
+	Percent int
+}
+
+type htmlSeverityGroup struct {
+	Severity   rules.Severity
+	Count      int
+	Categories []htmlCategoryGroup
+}
+
+type htmlCategoryGroup struct {
+	Category rules.RuleCategory
+	Count    int
+	Files    []htmlFileGroup
+}
+
+type htmlFileGroup struct {
+	FileName string
+	Findings []htmlFinding
+}
+
+type htmlFinding struct {
+	ID              rules.RuleID
+	Name            string
+	Description     string
+	LineNumber      int
+	IsFalsePositive bool
+	// The line content is split around the column range, which is highlighted
+	BeforeHighlight string
+	Highlight       string
+	AfterHighlight  string
+}
+
+/**
+ * writeHTMLOutput - method used to write the scan result as a single HTML file which can be opened offline
+ */
+func writeHTMLOutput(w io.Writer, finalResult *finding.Output) error {
+	reportTemplate, err := template.New("report").Parse(htmlReportTemplate)
+	if err != nil {
+		return err
+	}
+	return reportTemplate.Execute(w, createHTMLReport(finalResult))
+}
+
+/**
+ * createHTMLReport - method used to group the findings by severity, rule category and file for the HTML report
+ */
+func createHTMLReport(finalResult *finding.Output) htmlReport {
+	report := htmlReport{
+		ToolName:        toolName,
+		ScanStartedTime: finalResult.ScanStartedTime,
+		ScanEndingTime:  finalResult.ScanEndingTime,
+		Count:           len(finalResult.Results),
+		Diagnostics:     finalResult.Diagnostics,
+	}
+	findingsPerSeverity := map[rules.Severity][]finding.Finding{}
+	categoryCounts := map[string]int{}
+	for _, result := range finalResult.Results {
+		findingsPerSeverity[result.Severity] = append(findingsPerSeverity[result.Severity], result)
+		categoryCounts[string(result.RuleCategory)]++
+	}
+
+	for _, severity := range getSortedSeverities(findingsPerSeverity) {
+		severityFindings := findingsPerSeverity[severity]
+		report.Severities = append(report.Severities, htmlSeverityGroup{
+			Severity:   severity,
+			Count:      len(severityFindings),
+			Categories: createHTMLCategoryGroups(severityFindings),
+		})
+		report.SeverityCounts = append(report.SeverityCounts, htmlCount{Name: string(severity), Count: len(severityFindings)})
+	}
+	setHTMLPercents(report.SeverityCounts)
+	report.CategoryCounts = createSortedHTMLCounts(categoryCounts)
+	setHTMLPercents(report.CategoryCounts)
+	return report
+}
+
+/**
+ * getSortedSeverities - method used to get the severities with findings, from the most to the least severe.
+ *	Unknown severities, e.g. from custom rules, come last sorted by name.
+ */
+func getSortedSeverities(findingsPerSeverity map[rules.Severity][]finding.Finding) []rules.Severity {
+	severities := []rules.Severity{}
+	isKnownSeverity := map[rules.Severity]bool{}
+	for _, severity := range severityOrder {
+		isKnownSeverity[severity] = true
+		if len(findingsPerSeverity[severity]) > 0 {
+			severities = append(severities, severity)
+		}
+	}
+	otherSeverities := []rules.Severity{}
+	for severity := range findingsPerSeverity {
+		if !isKnownSeverity[severity] {
+			otherSeverities = append(otherSeverities, severity)
+		}
+	}
+	sort.Slice(otherSeverities, func(i, j int) bool {
+		return otherSeverities[i] < otherSeverities[j]
+	})
+	return append(severities, otherSeverities...)
+}
+
+/**
+ * createHTMLCategoryGroups - method used to group the findings of a severity by rule category and file, both sorted by name
+ */
+func createHTMLCategoryGroups(results []finding.Finding) []htmlCategoryGroup {
+	findingsPerCategory := map[rules.RuleCategory]map[string][]htmlFinding{}
+	categoryCounts := map[rules.RuleCategory]int{}
+	for _, result := range results {
+		if findingsPerCategory[result.RuleCategory] == nil {
+			findingsPerCategory[result.RuleCategory] = map[string][]htmlFinding{}
+		}
+		fileName := result.Occurrence.FileName
+		findingsPerCategory[result.RuleCategory][fileName] = append(findingsPerCategory[result.RuleCategory][fileName], createHTMLFinding(result))
+		categoryCounts[result.RuleCategory]++
+	}
+
+	categories := make([]rules.RuleCategory, 0, len(findingsPerCategory))
+	for category := range findingsPerCategory {
+		categories = append(categories, category)
+	}
+	sort.Slice(categories, func(i, j int) bool {
+		return categories[i] < categories[j]
+	})
+	categoryGroups := []htmlCategoryGroup{}
+	for _, category := range categories {
+		fileNames := make([]string, 0, len(findingsPerCategory[category]))
+		for fileName := range findingsPerCategory[category] {
+			fileNames = append(fileNames, fileName)
+		}
+		sort.Strings(fileNames)
+		categoryGroup := htmlCategoryGroup{Category: category, Count: categoryCounts[category]}
+		for _, fileName := range fileNames {
+			fileFindings := findingsPerCategory[category][fileName]
+			sort.SliceStable(fileFindings, func(i, j int) bool {
+				return fileFindings[i].LineNumber < fileFindings[j].LineNumber
+			})
+			categoryGroup.Files = append(categoryGroup.Files, htmlFileGroup{FileName: fileName, Findings: fileFindings})
+		}
+		categoryGroups = append(categoryGroups, categoryGroup)
+	}
+	return categoryGroups
+}
+
+/**
+ * createHTMLFinding - method used to convert a finding for the HTML report, splitting its line content around the column range
+ */
+func createHTMLFinding(result finding.Finding) htmlFinding {
+	htmlResult := htmlFinding{
+		ID:              result.ID,
+		Name:            result.Name,
+		Description:     result.Description,
+		LineNumber:      result.Occurrence.LineNumber,
+		IsFalsePositive: result.Occurrence.IsFalsePositive,
+		BeforeHighlight: result.Occurrence.LineContent,
+	}
+	lineContent := result.Occurrence.LineContent
+	columnRange := result.Occurrence.ColumnRange
+	// ColumnRange is 0-based and end exclusive, ignore the ranges which do not fit the line content
+	if len(columnRange) == 2 && columnRange[0] >= 0 && columnRange[0] < columnRange[1] && columnRange[1] <= len(lineContent) {
+		htmlResult.BeforeHighlight = lineContent[:columnRange[0]]
+		htmlResult.Highlight = lineContent[columnRange[0]:columnRange[1]]
+		htmlResult.AfterHighlight = lineContent[columnRange[1]:]
+	}
+	return htmlResult
+}
+
+/**
+ * createSortedHTMLCounts - method used to convert counts by name into the bars of a chart, sorted by name
+ */
+func createSortedHTMLCounts(counts map[string]int) []htmlCount {
+	htmlCounts := []htmlCount{}
+	for name, count := range counts {
+		htmlCounts = append(htmlCounts, htmlCount{Name: name, Count: count})
+	}
+	sort.Slice(htmlCounts, func(i, j int) bool {
+		return htmlCounts[i].Name < htmlCounts[j].Name
+	})
+	return htmlCounts
+}
+
+/**
+ * setHTMLPercents - method used to set the width of the bars of a chart, relative to the largest bar
+ */
+func setHTMLPercents(htmlCounts []htmlCount) {
+	maxCount := 0
+	for _, htmlCount := range htmlCounts {
+		maxCount = max(maxCount, htmlCount.Count)
+	}
+	for index := range htmlCounts {
+		if maxCount > 0 {
+			htmlCounts[index].Percent = htmlCounts[index].Count * 100 / maxCount
+		}
+	}
+}
@@ -0,0 +1,61 @@
+package output
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+
+	"github.com/certinia/asist/finding"
+	"github.com/certinia/asist/rules"
+)
+
+func TestCreateHTMLReport_WhenFindingsExist_GroupsBySeverityCategoryAndFile(t *testing.T) {
+	//Given
+	finalResult := &finding.Output{
+		Results: []finding.Finding{
+			{ID: "ApexClassNoSharing", Severity: rules.SeverityMedium, RuleCategory: rules.CategorySecurity, Occurrence: rules.Occurrence{FileName: "/src/b.cls", LineNumber: 1}},
+			{ID: "XSSLabel", Severity: rules.SeverityHigh, RuleCategory: rules.CategorySecurity, Occurrence: rules.Occurrence{FileName: "/src/b.page", LineNumber: 9}},
+			{ID: "XSSLabel", Severity: rules.SeverityHigh, RuleCategory: rules.CategorySecurity, Occurrence: rules.Occurrence{FileName: "/src/a.page", LineNumber: 4}},
+			{ID: "XSSLabel", Severity: rules.SeverityHigh, RuleCategory: rules.CategorySecurity, Occurrence: rules.Occurrence{FileName: "/src/b.page", LineNumber: 2}},
+		},
+	}
+
+	//When
+	actualResult := createHTMLReport(finalResult)
+
+	//Then
+	if len(actualResult.Severities) != 2 || actualResult.Severities[0].Severity != rules.SeverityHigh || actualResult.Severities[1].Severity != rules.SeverityMedium {
+		t.Fatalf("Severities should be sorted from the most severe. Actual: %+v", actualResult.Severities)
+	}
+	files := actualResult.Severities[0].Categories[0].Files
+	if len(files) != 2 || files[0].FileName != "/src/a.page" || files[1].FileName != "/src/b.page" {
+		t.Fatalf("Files should be sorted by name. Actual: %+v", files)
+	}
+	if files[1].Findings[0].LineNumber != 2 || files[1].Findings[1].LineNumber != 9 {
+		t.Errorf("Findings of a file should be sorted by line. Actual: %+v", files[1].Findings)
+	}
+	if actualResult.SeverityCounts[0].Percent != 100 || actualResult.SeverityCounts[1].Percent != 33 {
+		t.Errorf("Chart bars should be relative to the largest bar. Actual: %+v", actualResult.SeverityCounts)
+	}
+}
+
+func TestWriteHTMLOutput_WhenFindingHasColumnRange_HighlightsEscapedLineContent(t *testing.T) {
+	//Given
+	finalResult := &finding.Output{
+		Results: []finding.Finding{
+			{ID: "XSSLabel", Name: "XSS Label", Severity: rules.SeverityHigh, RuleCategory: rules.CategorySecurity, Occurrence: rules.Occurrence{FileName: "/src/a.page", LineNumber: 4, LineContent: "<b>{!$Label.abc}</b>", ColumnRange: []int{3, 16}}},
+		},
+	}
+	var buf bytes.Buffer
+
+	//When
+	err := writeHTMLOutput(&buf, finalResult)
+
+	//Then
+	if err != nil {
+		t.Fatalf("Should not return any error! %v", err)
+	}
+	if !strings.Contains(buf.String(), "<pre>&lt;b&gt;<mark>{!$Label.abc}</mark>&lt;/b&gt;</pre>") {
+		t.Errorf("Expected the escaped line content with the column range highlighted, got: %s", buf.String())
+	}
+}
@@ -0,0 +1,133 @@
+package output
+
+import (
+	"encoding/xml"
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/certinia/asist/config"
+	"github.com/certinia/asist/finding"
+	"github.com/certinia/asist/message"
+	"github.com/certinia/asist/rules"
+)
+
+type junitTestSuites struct {
+	XMLName    xml.Name         `xml:"testsuites"`
+	Name       string           `xml:"name,attr"`
+	Tests      int              `xml:"tests,attr"`
+	Failures   int              `xml:"failures,attr"`
+	TestSuites []junitTestSuite `xml:"testsuite"`
+}
+
+type junitTestSuite struct {
+	Name      string          `xml:"name,attr"`
+	Tests     int             `xml:"tests,attr"`
+	Failures  int             `xml:"failures,attr"`
+	TestCases []junitTestCase `xml:"testcase"`
+}
+
+type junitTestCase struct {
+	Name      string        `xml:"name,attr"`
+	ClassName string        `xml:"classname,attr"`
+	Failure   *junitFailure `xml:"failure,omitempty"`
+}
+
+type junitFailure struct {
+	Message string `xml:"message,attr"`
+	Type    string `xml:"type,attr"`
+	Text    string `xml:",cdata"`
+}
+
+/**
+ * createJUnitOutput - method used to convert the scan result into a JUnit XML report.
+ *	Each rule run is a testsuite and each file with findings of the rule is a testcase, a rule without findings has a single passing testcase.
+ *	The testcases of a rule fail when the findings checked by the CI/CD thresholds (thresholdResult, e.g. the findings not in
+ *	the --baseline file) exceed the cicdmaxissues of the rule, only the files with such findings fail.
+ */
+func createJUnitOutput(finalResult *finding.Output, thresholdResult *finding.Output, ruleMetadata []*rules.RuleMetadata, configFile *config.Config) junitTestSuites {
+	findingsPerRule := map[rules.RuleID][]finding.Finding{}
+	for _, result := range finalResult.Results {
+		findingsPerRule[result.ID] = append(findingsPerRule[result.ID], result)
+	}
+	thresholdFindingsPerRule := map[rules.RuleID][]finding.Finding{}
+	for _, result := range thresholdResult.Results {
+		thresholdFindingsPerRule[result.ID] = append(thresholdFindingsPerRule[result.ID], result)
+	}
+	ruleNames := map[rules.RuleID]string{}
+	for _, metadata := range ruleMetadata {
+		ruleNames[metadata.ID] = metadata.Name
+	}
+	// Sort rule IDs for deterministic output
+	sortedRuleIds := make([]rules.RuleID, 0, len(ruleNames)+len(findingsPerRule))
+	for ruleId := range ruleNames {
+		sortedRuleIds = append(sortedRuleIds, ruleId)
+	}
+	for ruleId := range findingsPerRule {
+		if _, isRuleRun := ruleNames[ruleId]; !isRuleRun {
+			sortedRuleIds = append(sortedRuleIds, ruleId)
+		}
+	}
+	sort.Slice(sortedRuleIds, func(i, j int) bool {
+		return string(sortedRuleIds[i]) < string(sortedRuleIds[j])
+	})
+
+	report := junitTestSuites{Name: toolName, TestSuites: []junitTestSuite{}}
+	for _, ruleId := range sortedRuleIds {
+		ruleFindings := findingsPerRule[ruleId]
+		thresholdFindings := thresholdFindingsPerRule[ruleId]
+		maxIssuesAllowed := configFile.GetRuleCicdMaxIssues(ruleId)
+		isViolated := len(thresholdFindings) > maxIssuesAllowed
+
+		testSuite := junitTestSuite{Name: string(ruleId)}
+		if len(ruleFindings) == 0 {
+			testSuite.TestCases = append(testSuite.TestCases, junitTestCase{Name: ruleNames[ruleId], ClassName: string(ruleId)})
+		}
+		for _, fileName := range getSortedFileNames(ruleFindings) {
+			testCase := junitTestCase{Name: fileName, ClassName: string(ruleId)}
+			if failureText := createJUnitFailureText(thresholdFindings, fileName); isViolated && failureText != "" {
+				testCase.Failure = &junitFailure{
+					Message: strings.TrimSpace(message.GetThresholdViolation(string(ruleId), len(thresholdFindings), maxIssuesAllowed)),
+					Type:    string(ruleFindings[0].Severity),
+					Text:    failureText,
+				}
+				testSuite.Failures++
+			}
+			testSuite.TestCases = append(testSuite.TestCases, testCase)
+		}
+		testSuite.Tests = len(testSuite.TestCases)
+		report.Tests += testSuite.Tests
+		report.Failures += testSuite.Failures
+		report.TestSuites = append(report.TestSuites, testSuite)
+	}
+	return report
+}
+
+/**
+ * getSortedFileNames - method used to get the distinct files of findings, sorted by name
+ */
+func getSortedFileNames(results []finding.Finding) []string {
+	fileNames := []string{}
+	isFileNameAdded := map[string]bool{}
+	for _, result := range results {
+		if !isFileNameAdded[result.Occurrence.FileName] {
+			isFileNameAdded[result.Occurrence.FileName] = true
+			fileNames = append(fileNames, result.Occurrence.FileName)
+		}
+	}
+	sort.Strings(fileNames)
+	return fileNames
+}
+
+/**
+ * createJUnitFailureText - method used to list the findings of a rule in a file, one line per finding
+ */
+func createJUnitFailureText(results []finding.Finding, fileName string) string {
+	var failureText strings.Builder
+	for _, result := range results {
+		if result.Occurrence.FileName == fileName {
+			fmt.Fprintf(&failureText, "%s:%d: %s\n", fileName, result.Occurrence.LineNumber, result.Name)
+		}
+	}
+	return failureText.String()
+}
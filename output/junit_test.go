@@ -0,0 +1,97 @@
+package output
+
+import (
+	"encoding/xml"
+	"testing"
+
+	"github.com/certinia/asist/config"
+	"github.com/certinia/asist/finding"
+	"github.com/certinia/asist/rules"
+)
+
+func TestCreateJUnitOutput_WhenRuleExceedsThreshold_FailsTestCasesOfRule(t *testing.T) {
+	//Given
+	finalResult := &finding.Output{
+		Results: []finding.Finding{
+			{ID: "XSSLabel", Name: "XSS Label", Severity: rules.SeverityHigh, Occurrence: rules.Occurrence{FileName: "/src/page/b.page", LineNumber: 4}},
+			{ID: "ApexClassNoSharing", Name: "Apex Class No Sharing", Severity: rules.SeverityMedium, Occurrence: rules.Occurrence{FileName: "/src/class/a.cls", LineNumber: 1}},
+			{ID: "XSSLabel", Name: "XSS Label", Severity: rules.SeverityHigh, Occurrence: rules.Occurrence{FileName: "/src/page/a.page", LineNumber: 2}},
+			{ID: "XSSLabel", Name: "XSS Label", Severity: rules.SeverityHigh, Occurrence: rules.Occurrence{FileName: "/src/page/b.page", LineNumber: 9}},
+		},
+	}
+	configFile := &config.Config{RuleOverrides: map[string]rules.RuleMetadataOverride{"ApexClassNoSharing": {CicdMaxIssues: intPtr(1)}}}
+
+	//When
+	actualResult := createJUnitOutput(finalResult, finalResult, nil, configFile)
+
+	//Then
+	if actualResult.Tests != 3 || actualResult.Failures != 2 || len(actualResult.TestSuites) != 2 {
+		t.Fatalf("Expected 3 testcases with 2 failures in 2 testsuites. Actual: %+v", actualResult)
+	}
+	apexSuite, xssSuite := actualResult.TestSuites[0], actualResult.TestSuites[1]
+	if apexSuite.Name != "ApexClassNoSharing" || apexSuite.Failures != 0 || apexSuite.TestCases[0].Failure != nil {
+		t.Errorf("Rule within its threshold should pass. Actual: %+v", apexSuite)
+	}
+	if xssSuite.Name != "XSSLabel" || xssSuite.Tests != 2 || xssSuite.TestCases[0].Name != "/src/page/a.page" || xssSuite.TestCases[1].Name != "/src/page/b.page" {
+		t.Fatalf("Testcases should be the files of the rule sorted by name. Actual: %+v", xssSuite)
+	}
+	expectedText := "/src/page/b.page:4: XSS Label\n/src/page/b.page:9: XSS Label\n"
+	if failure := xssSuite.TestCases[1].Failure; failure == nil || failure.Text != expectedText || failure.Type != "High" {
+		t.Errorf("Failure should list the findings of the file. Actual: %+v", failure)
+	}
+}
+
+func TestCreateJUnitOutput_WhenNoFindings_ReturnsEmptyTestSuites(t *testing.T) {
+	//Given
+	finalResult := &finding.Output{Results: []finding.Finding{}}
+
+	//When
+	xmlOutput, err := xml.Marshal(createJUnitOutput(finalResult, finalResult, nil, nil))
+
+	//Then
+	if err != nil {
+		t.Fatalf("Should not return error while marshalling: %v", err)
+	}
+	expected := `<testsuites name="ASIST" tests="0" failures="0"></testsuites>`
+	if string(xmlOutput) != expected {
+		t.Errorf("JUnit output mismatched.\n Actual %s\n Expected %s", xmlOutput, expected)
+	}
+}
+
+func TestCreateJUnitOutput_WhenRulesRunHaveNoFindings_ReturnsPassingTestCasePerRule(t *testing.T) {
+	//Given
+	finalResult := &finding.Output{Results: []finding.Finding{}}
+	ruleMetadata := []*rules.RuleMetadata{{ID: "XSSLabel", Name: "XSS Label"}, {ID: "ApexClassNoSharing", Name: "Apex Class No Sharing"}}
+
+	//When
+	actualResult := createJUnitOutput(finalResult, finalResult, ruleMetadata, nil)
+
+	//Then
+	if actualResult.Tests != 2 || actualResult.Failures != 0 || len(actualResult.TestSuites) != 2 {
+		t.Fatalf("Expected a passing testcase per rule run. Actual: %+v", actualResult)
+	}
+	apexSuite := actualResult.TestSuites[0]
+	if apexSuite.Name != "ApexClassNoSharing" || apexSuite.Tests != 1 || apexSuite.TestCases[0].Name != "Apex Class No Sharing" || apexSuite.TestCases[0].Failure != nil {
+		t.Errorf("Rule without findings should have a passing testcase. Actual: %+v", apexSuite)
+	}
+}
+
+func TestCreateJUnitOutput_WhenFindingsAreInBaseline_FailsOnlyOnNewFindings(t *testing.T) {
+	//Given
+	baselineFinding := finding.Finding{ID: "XSSLabel", Name: "XSS Label", Severity: rules.SeverityHigh, Occurrence: rules.Occurrence{FileName: "/src/page/a.page", LineNumber: 2}}
+	newFinding := finding.Finding{ID: "XSSLabel", Name: "XSS Label", Severity: rules.SeverityHigh, Occurrence: rules.Occurrence{FileName: "/src/page/b.page", LineNumber: 4}}
+	finalResult := &finding.Output{Results: []finding.Finding{baselineFinding, newFinding}}
+	configFile := &config.Config{RuleOverrides: map[string]rules.RuleMetadataOverride{"XSSLabel": {CicdMaxIssues: intPtr(1)}}}
+
+	//When
+	onlyBaselineResult := createJUnitOutput(finalResult, &finding.Output{Results: []finding.Finding{}}, nil, configFile)
+	withNewResult := createJUnitOutput(finalResult, &finding.Output{Results: []finding.Finding{newFinding, newFinding}}, nil, configFile)
+
+	//Then
+	if onlyBaselineResult.Tests != 2 || onlyBaselineResult.Failures != 0 {
+		t.Errorf("Findings in the baseline should not fail. Actual: %+v", onlyBaselineResult)
+	}
+	if withNewResult.Failures != 1 || withNewResult.TestSuites[0].TestCases[0].Failure != nil || withNewResult.TestSuites[0].TestCases[1].Failure == nil {
+		t.Errorf("Only the file with new findings should fail. Actual: %+v", withNewResult)
+	}
+}
@@ -0,0 +1,135 @@
+package output
+
+import (
+	"fmt"
+	"io"
+	"path"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/certinia/asist/files"
+	"github.com/certinia/asist/finding"
+	"github.com/certinia/asist/message"
+	"github.com/certinia/asist/parser/options"
+	"github.com/certinia/asist/rules"
+)
+
+// markdownCellEscaper escapes the characters which would break the cells of a markdown table
+var markdownCellEscaper = strings.NewReplacer("|", "\\|", "\n", " ", "\r", "")
+
+/**
+ * writeMarkdownOutput - method used to write summary tables of the findings per rule and per directory, e.g. for a pull request comment.
+ *	When groupBy is set, a table per author or age of the commits of the findings is added.
+ */
+func writeMarkdownOutput(w io.Writer, finalResult *finding.Output, groupBy string) {
+	fmt.Fprintf(w, "## %s findings\n\n", toolName)
+	if len(finalResult.Results) == 0 {
+		fmt.Fprintf(w, "%s\n", message.GetNoFindingsSummary())
+		return
+	}
+	writeMarkdownTables(w, finalResult.Results, "Findings")
+	if groupBy != "" {
+		writeMarkdownGroupTable(w, finalResult.Results, "Findings", groupBy)
+	}
+}
+
+/**
+ * writeMarkdownTables - method used to write the summary of the findings per severity followed by the tables per rule and per directory
+ */
+func writeMarkdownTables(w io.Writer, results []finding.Finding, title string) {
+	findingsPerSeverity := map[rules.Severity][]finding.Finding{}
+	findingsPerRule := map[rules.RuleID][]finding.Finding{}
+	findingsPerDirectory := map[string]map[rules.Severity]int{}
+	for _, result := range results {
+		findingsPerSeverity[result.Severity] = append(findingsPerSeverity[result.Severity], result)
+		findingsPerRule[result.ID] = append(findingsPerRule[result.ID], result)
+		directory := path.Dir(files.GetRepoRelativePath(result.Occurrence.FileName))
+		if findingsPerDirectory[directory] == nil {
+			findingsPerDirectory[directory] = map[rules.Severity]int{}
+		}
+		findingsPerDirectory[directory][result.Severity]++
+	}
+	severities := getSortedSeverities(findingsPerSeverity)
+
+	severityCounts := []string{}
+	for _, severity := range severities {
+		severityCounts = append(severityCounts, fmt.Sprintf("%d %s", len(findingsPerSeverity[severity]), severity))
+	}
+	fmt.Fprintf(w, "%s\n\n", message.GetTextSummary(len(results), strings.Join(severityCounts, ", ")))
+
+	// Rules are sorted from the most severe, then by ID
+	severityRanks := map[rules.Severity]int{}
+	for rank, severity := range severities {
+		severityRanks[severity] = rank
+	}
+	ruleIds := make([]rules.RuleID, 0, len(findingsPerRule))
+	for ruleId := range findingsPerRule {
+		ruleIds = append(ruleIds, ruleId)
+	}
+	sort.Slice(ruleIds, func(i, j int) bool {
+		iRank, jRank := severityRanks[findingsPerRule[ruleIds[i]][0].Severity], severityRanks[findingsPerRule[ruleIds[j]][0].Severity]
+		if iRank != jRank {
+			return iRank < jRank
+		}
+		return ruleIds[i] < ruleIds[j]
+	})
+	fmt.Fprintf(w, "### %s per rule\n\n| Rule | Name | Severity | Category | Findings |\n| --- | --- | --- | --- | ---: |\n", title)
+	for _, ruleId := range ruleIds {
+		ruleFindings := findingsPerRule[ruleId]
+		fmt.Fprintf(w, "| %s | %s | %s | %s | %d |\n",
+			markdownCellEscaper.Replace(string(ruleId)),
+			markdownCellEscaper.Replace(ruleFindings[0].Name),
+			markdownCellEscaper.Replace(string(ruleFindings[0].Severity)),
+			markdownCellEscaper.Replace(string(ruleFindings[0].RuleCategory)),
+			len(ruleFindings))
+	}
+
+	directories := make([]string, 0, len(findingsPerDirectory))
+	for directory := range findingsPerDirectory {
+		directories = append(directories, directory)
+	}
+	sort.Strings(directories)
+	writeMarkdownSeverityTable(w, fmt.Sprintf("%s per directory", title), "Directory", directories, findingsPerDirectory, severities)
+}
+
+/**
+ * writeMarkdownGroupTable - method used to write the table of the findings per author or age of their commit
+ */
+func writeMarkdownGroupTable(w io.Writer, results []finding.Finding, title string, groupBy string) {
+	groups, findingsPerGroup := groupFindings(results, groupBy, time.Now())
+	findingsPerSeverity := map[rules.Severity][]finding.Finding{}
+	severityCountsPerGroup := map[string]map[rules.Severity]int{}
+	for _, group := range groups {
+		severityCountsPerGroup[group] = map[rules.Severity]int{}
+		for _, result := range findingsPerGroup[group] {
+			findingsPerSeverity[result.Severity] = append(findingsPerSeverity[result.Severity], result)
+			severityCountsPerGroup[group][result.Severity]++
+		}
+	}
+	column := "Author"
+	if groupBy == options.GroupByAge {
+		column = "Age"
+	}
+	writeMarkdownSeverityTable(w, fmt.Sprintf("%s per %s", title, groupBy), column, groups, severityCountsPerGroup, getSortedSeverities(findingsPerSeverity))
+}
+
+/**
+ * writeMarkdownSeverityTable - method used to write a table of the number of findings per severity of each row
+ */
+func writeMarkdownSeverityTable(w io.Writer, heading string, column string, rows []string, severityCountsPerRow map[string]map[rules.Severity]int, severities []rules.Severity) {
+	fmt.Fprintf(w, "\n### %s\n\n| %s |", heading, column)
+	for _, severity := range severities {
+		fmt.Fprintf(w, " %s |", markdownCellEscaper.Replace(string(severity)))
+	}
+	fmt.Fprintf(w, " Total |\n| --- |%s ---: |\n", strings.Repeat(" ---: |", len(severities)))
+	for _, row := range rows {
+		total := 0
+		fmt.Fprintf(w, "| %s |", markdownCellEscaper.Replace(row))
+		for _, severity := range severities {
+			fmt.Fprintf(w, " %d |", severityCountsPerRow[row][severity])
+			total += severityCountsPerRow[row][severity]
+		}
+		fmt.Fprintf(w, " %d |\n", total)
+	}
+}
@@ -0,0 +1,60 @@
+package output
+
+import (
+	"bytes"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/certinia/asist/finding"
+	"github.com/certinia/asist/rules"
+)
+
+func TestWriteMarkdownOutput_WhenFindingsExist_WritesTablesPerRuleAndDirectory(t *testing.T) {
+	//Given
+	rootPath := t.TempDir()
+	os.MkdirAll(filepath.Join(rootPath, ".git"), 0750)
+	finalResult := &finding.Output{
+		Results: []finding.Finding{
+			{ID: "ApexClassNoSharing", Name: "Apex Class No Sharing", Severity: rules.SeverityMedium, RuleCategory: rules.CategorySecurity, Occurrence: rules.Occurrence{FileName: filepath.Join(rootPath, "classes", "a.cls")}},
+			{ID: "XSSLabel", Name: "XSS | Label", Severity: rules.SeverityHigh, RuleCategory: rules.CategorySecurity, Occurrence: rules.Occurrence{FileName: filepath.Join(rootPath, "pages", "a.page")}},
+			{ID: "XSSLabel", Name: "XSS | Label", Severity: rules.SeverityHigh, RuleCategory: rules.CategorySecurity, Occurrence: rules.Occurrence{FileName: filepath.Join(rootPath, "classes", "b.cls")}},
+		},
+	}
+	var buf bytes.Buffer
+
+	//When
+	writeMarkdownOutput(&buf, finalResult, "")
+
+	//Then
+	expected := "## ASIST findings\n\n" +
+		"3 finding(s): 2 High, 1 Medium\n\n" +
+		"### Findings per rule\n\n" +
+		"| Rule | Name | Severity | Category | Findings |\n" +
+		"| --- | --- | --- | --- | ---: |\n" +
+		"| XSSLabel | XSS \\| Label | High | Security | 2 |\n" +
+		"| ApexClassNoSharing | Apex Class No Sharing | Medium | Security | 1 |\n" +
+		"\n### Findings per directory\n\n" +
+		"| Directory | High | Medium | Total |\n" +
+		"| --- | ---: | ---: | ---: |\n" +
+		"| classes | 1 | 1 | 2 |\n" +
+		"| pages | 1 | 0 | 1 |\n"
+	if buf.String() != expected {
+		t.Errorf("Markdown output mismatched.\n Actual %q\n Expected %q", buf.String(), expected)
+	}
+}
+
+func TestWriteMarkdownOutput_WhenNoFindings_WritesNoFindingsSummary(t *testing.T) {
+	//Given
+	finalResult := &finding.Output{Results: []finding.Finding{}}
+	var buf bytes.Buffer
+
+	//When
+	writeMarkdownOutput(&buf, finalResult, "")
+
+	//Then
+	expected := "## ASIST findings\n\nNo findings\n"
+	if buf.String() != expected {
+		t.Errorf("Markdown output mismatched.\n Actual %q\n Expected %q", buf.String(), expected)
+	}
+}
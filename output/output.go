@@ -2,6 +2,7 @@ package output
 
 import (
 	"encoding/json"
+	"encoding/xml"
 	"fmt"
 	"io"
 	"os"
@@ -11,6 +12,7 @@ import (
 
 	"time"
 
+	"github.com/certinia/asist/baseline"
 	"github.com/certinia/asist/config"
 	"github.com/certinia/asist/debugger"
 	"github.com/certinia/asist/errorhandler"
@@ -27,21 +29,27 @@ type ScanTime struct {
 }
 
 func ListRules(ruleInstances []*rules.Rule) {
-	allRulesMetadata := []*rules.RuleMetadata{}
 	if !options.IsListRules() {
 		return
 	}
-	for _, rule := range ruleInstances {
-		metadata := (*rule).GetMetadata()
-		allRulesMetadata = append(allRulesMetadata, metadata)
-	}
 
-	fmt.Printf("%s\n", PrettyPrintJSON(allRulesMetadata))
+	fmt.Printf("%s\n", PrettyPrintJSON(GetRulesMetadata(ruleInstances)))
 
 	debugger.Debug("listed rules")
 	os.Exit(int(errorhandler.ExitCodeSuccess))
 }
 
+/**
+ * GetRulesMetadata - method used to get the metadata of the rules, as listed by --list-rules
+ */
+func GetRulesMetadata(ruleInstances []*rules.Rule) []*rules.RuleMetadata {
+	allRulesMetadata := []*rules.RuleMetadata{}
+	for _, rule := range ruleInstances {
+		allRulesMetadata = append(allRulesMetadata, (*rule).GetMetadata())
+	}
+	return allRulesMetadata
+}
+
 /**
  * CheckThresholdViolations checks if any rule exceeds its configured cicdmaxissues.
  * Default cicdmaxissues is 0 (no issues allowed).
@@ -87,29 +95,94 @@ func CheckThresholdViolations(w io.Writer, finalResult *finding.Output, configFi
 }
 
 /**
- * DisplayOutput - method used to display the output of scans by type
+ * ReportBaselineComparison - method used to report the number of new findings and the findings fixed since the baseline
+ */
+func ReportBaselineComparison(w io.Writer, comparison baseline.Comparison) {
+	fmt.Fprintf(w, "\n%s\n", message.GetBaselineSummary(len(comparison.New), len(comparison.Fixed)))
+	for _, entry := range comparison.Fixed {
+		fmt.Fprintf(w, "%s\n", message.GetBaselineFixedFinding(string(entry.RuleID), entry.FileName, entry.LineNumber))
+	}
+}
+
+/**
+ * ReportDiagnostics - method used to report the files which could not be fully scanned, e.g. skipped on timeout
+ */
+func ReportDiagnostics(w io.Writer, diagnostics []finding.Diagnostic) {
+	for _, diagnostic := range diagnostics {
+		fmt.Fprintf(w, "%s\n", message.GetDiagnosticWarning(diagnostic.FileName, diagnostic.Status, diagnostic.Reason, diagnostic.Message))
+	}
+}
+
+/**
+ * filterBaselineFindings - method used to compare the findings with the baseline file and return only the new findings
+ */
+func filterBaselineFindings(finalResult *finding.Output, baselinePath string) *finding.Output {
+	existingBaseline, err := baseline.Read(baselinePath)
+	if err != nil {
+		errorhandler.ExitWithError(err)
+	}
+	comparison := existingBaseline.Compare(finalResult.Results)
+	ReportBaselineComparison(os.Stderr, comparison)
+	debugger.Debug("compared findings with baseline")
+	return &finding.Output{Count: len(comparison.New), Results: comparison.New}
+}
+
+/**
+ * DisplayOutput - method used to display the output of scans by type, ruleInstances are the rules run by the scan
  */
-func DisplayOutput(finalResult *finding.Output, scanTime *ScanTime) {
+func DisplayOutput(finalResult *finding.Output, ruleInstances []*rules.Rule, scanTime *ScanTime) {
+	ReportDiagnostics(os.Stderr, finalResult.Diagnostics)
+	ruleMetadata := GetRulesMetadata(ruleInstances)
 	if options.IsBaselineScan() {
 		debugger.Debug("writing baseline output")
-		baselineScanOutput := createBaselineOutput(finalResult, options.GetRepoURL())
-		displayOutput(baselineScanOutput)
+		finalResult.Count = len(finalResult.Results)
+		for _, outputTarget := range options.GetOutputTargets() {
+			writeBaselineScanOutput(outputTarget, finalResult, ruleMetadata, options.GetRepoURL())
+		}
 	} else {
 		debugger.Debug("writing regular output")
 		scanTime.EndingTime = time.Now().String()
 		finalResult.ScanStartedTime = scanTime.StartedTime
 		finalResult.ScanEndingTime = scanTime.EndingTime
 		finalResult.Count = len(finalResult.Results)
-		displayOutput(finalResult)
 
-		configFile := config.GetConfigInstance()
+		// The outputs which check the CI/CD thresholds, like JUnit, only check the findings which are not in the baseline
+		thresholdResult := finalResult
+		if baselinePath := options.GetBaseline(); baselinePath != "" {
+			thresholdResult = filterBaselineFindings(finalResult, baselinePath)
+		}
+		for _, outputTarget := range options.GetOutputTargets() {
+			writeScanOutput(outputTarget, finalResult, thresholdResult, ruleMetadata)
+		}
+
+		if writeBaselinePath := options.GetWriteBaseline(); writeBaselinePath != "" {
+			if err := baseline.Write(writeBaselinePath, finalResult.Results); err != nil {
+				errorhandler.ExitWithError(err)
+			}
+			debugger.Debug("wrote baseline file")
+		}
 
-		if options.IsCICDScan() && CheckThresholdViolations(os.Stderr, finalResult, configFile) {
+		configFile := config.GetConfigInstance()
+		if options.IsCICDScan() && CheckThresholdViolations(os.Stderr, thresholdResult, configFile) {
 			os.Exit(int(errorhandler.ExitCodeOccurrence))
 		}
 	}
 }
 
+/**
+ * writeBaselineScanOutput - method used to write the records of a baseline scan in the json format,
+ *	the other formats are written like a regular scan, with the false positive findings
+ */
+func writeBaselineScanOutput(outputTarget options.OutputTarget, finalResult *finding.Output, ruleMetadata []*rules.RuleMetadata, repoURL string) {
+	if outputTarget.Format != options.FormatJSON {
+		writeScanOutput(outputTarget, finalResult, finalResult, ruleMetadata)
+		return
+	}
+	w, closeOutput := openOutput(outputTarget.Path)
+	displayOutput(w, createBaselineOutput(finalResult, repoURL))
+	closeOutput()
+}
+
 /**
  * extractRepoNameFromURL - method used to extract repoName from a sshUrl of repository
  */
@@ -124,10 +197,75 @@ func extractRepoNameFromURL(url string) string {
 	return ""
 }
 
+/**
+ * writeOutput - method used to write the scan results in the format of the output target,
+ *	when the rules run are unknown and all findings are checked by the CI/CD thresholds
+ */
+func writeOutput(outputTarget options.OutputTarget, finalResult *finding.Output) {
+	writeScanOutput(outputTarget, finalResult, finalResult, nil)
+}
+
+/**
+ * writeScanOutput - method used to write the scan results in the format of the output target.
+ *	thresholdResult are the findings checked by the CI/CD thresholds and ruleMetadata the rules run, for the JUnit report.
+ */
+func writeScanOutput(outputTarget options.OutputTarget, finalResult *finding.Output, thresholdResult *finding.Output, ruleMetadata []*rules.RuleMetadata) {
+	w, closeOutput := openOutput(outputTarget.Path)
+	switch outputTarget.Format {
+	case options.FormatSarif:
+		displayOutput(w, createSarifOutput(finalResult))
+	case options.FormatJUnit:
+		displayXMLOutput(w, createJUnitOutput(finalResult, thresholdResult, ruleMetadata, config.GetConfigInstance()))
+	case options.FormatHTML:
+		if err := writeHTMLOutput(w, finalResult); err != nil {
+			errorhandler.ExitWithCode(message.GetOutputWriteError(outputTarget.Path, err), errorhandler.ExitCodeInternalError)
+		}
+	case options.FormatCheckstyle:
+		displayXMLOutput(w, createCheckstyleOutput(finalResult))
+	case options.FormatGithub:
+		writeGithubOutput(w, finalResult)
+	case options.FormatCSV:
+		if err := writeCSVOutput(w, finalResult); err != nil {
+			errorhandler.ExitWithCode(message.GetOutputWriteError(outputTarget.Path, err), errorhandler.ExitCodeInternalError)
+		}
+	case options.FormatMarkdown:
+		writeMarkdownOutput(w, finalResult, options.GetGroupBy())
+	case options.FormatGitlabSast:
+		displayOutput(w, createGitlabSastOutput(finalResult))
+	case options.FormatCodeClimate:
+		displayOutput(w, createCodeClimateOutput(finalResult))
+	case options.FormatText:
+		writeTextOutput(w, finalResult, isColorTerminal(w), options.GetGroupBy())
+	default:
+		displayOutput(w, finalResult)
+	}
+	closeOutput()
+}
+
+/**
+ * openOutput - method used to open the file the scan results are written into, or the standard output when no path is given.
+ *	The returned function closes the file and exits if the results could not be written.
+ */
+func openOutput(path string) (io.Writer, func()) {
+	if path == "" || path == "-" {
+		return os.Stdout, func() {}
+	}
+	outputFile, err := os.Create(path)
+	if err != nil {
+		errorhandler.ExitWithCode(message.GetOutputWriteError(path, err), errorhandler.ExitCodeUserError)
+	}
+	return outputFile, func() {
+		if err := outputFile.Close(); err != nil {
+			errorhandler.ExitWithCode(message.GetOutputWriteError(path, err), errorhandler.ExitCodeUserError)
+		}
+		debugger.Debug(fmt.Sprintf("wrote output file %s", path))
+	}
+}
+
 /**
  * displayOutput - method used to display the output of scans
  */
-func displayOutput(finalResult interface{}) {
+func displayOutput(w io.Writer, finalResult interface{}) {
 	var jsonOutput []byte
 	var err error
 	if options.IsBaselineScan() {
@@ -138,7 +276,18 @@ func displayOutput(finalResult interface{}) {
 	if err != nil {
 		errorhandler.ExitWithCode(message.GetMarshallingOutputError(err), errorhandler.ExitCodeInternalError)
 	}
-	fmt.Println(string(jsonOutput))
+	fmt.Fprintln(w, string(jsonOutput))
+}
+
+/**
+ * displayXMLOutput - method used to display the output of scans in an XML format, e.g. JUnit
+ */
+func displayXMLOutput(w io.Writer, finalResult interface{}) {
+	xmlOutput, err := xml.MarshalIndent(finalResult, "", " ")
+	if err != nil {
+		errorhandler.ExitWithCode(message.GetMarshallingOutputError(err), errorhandler.ExitCodeInternalError)
+	}
+	fmt.Fprintln(w, xml.Header+string(xmlOutput))
 }
 
 /**
@@ -154,6 +303,7 @@ func createBaselineOutput(finalResultList *finding.Output, repositoryURL string)
 		isCustom := !*ruleset.IsStandardRuleID(result.ID)
 		baselineOutputContent = finding.BaselineOutputContent{
 			FindingID:       result.CreateFindingID(),
+			Fingerprint:     result.Fingerprint,
 			IsCustom:        isCustom,
 			IsFalsePositive: result.Occurrence.IsFalsePositive,
 			Id:              result.ID,
@@ -2,12 +2,16 @@ package output
 
 import (
 	"bytes"
+	"os"
+	"path/filepath"
 	"reflect"
 	"strings"
 	"testing"
 
+	"github.com/certinia/asist/baseline"
 	"github.com/certinia/asist/config"
 	"github.com/certinia/asist/finding"
+	"github.com/certinia/asist/parser/options"
 	"github.com/certinia/asist/rules"
 )
 
@@ -364,3 +368,91 @@ func TestCheckThresholdViolations_OutputIsSortedByRuleID(t *testing.T) {
 		t.Errorf("Expected rules sorted alphabetically (A < M < Z), got: %s", output)
 	}
 }
+
+func TestReportBaselineComparison_WhenFindingsFixed_ReportsSummaryAndFixedFindings(t *testing.T) {
+	//Given
+	comparison := baseline.Comparison{
+		New:   []finding.Finding{{ID: "XSSLabel"}},
+		Fixed: []baseline.Entry{{RuleID: "SessionIDApex", FileName: "/src/a.cls", LineNumber: 4}},
+	}
+	var buf bytes.Buffer
+
+	//When
+	ReportBaselineComparison(&buf, comparison)
+
+	//Then
+	output := buf.String()
+	if !strings.Contains(output, "Baseline: 1 new finding(s), 1 finding(s) fixed since the baseline was recorded.") {
+		t.Errorf("Expected summary in output, got: %s", output)
+	}
+	if !strings.Contains(output, "Fixed SessionIDApex in /src/a.cls:4") {
+		t.Errorf("Expected fixed finding in output, got: %s", output)
+	}
+}
+
+func TestReportDiagnostics_WhenFileTimedOut_ReportsSkippedFile(t *testing.T) {
+	//Given
+	diagnostics := []finding.Diagnostic{
+		{FileName: "/src/Generated.cls", RuleID: "DetectMissingAccessibilityModifier", Status: finding.DiagnosticStatusSkipped, Reason: finding.DiagnosticReasonTimeout, Message: "Scan timed out"},
+	}
+	var buf bytes.Buffer
+
+	//When
+	ReportDiagnostics(&buf, diagnostics)
+
+	//Then
+	expected := "Warning: /src/Generated.cls skipped: timeout. Scan timed out\n"
+	if buf.String() != expected {
+		t.Errorf("Diagnostic mismatched. Actual: %q, Expected: %q", buf.String(), expected)
+	}
+}
+
+func TestWriteOutput_WhenTargetsHaveDifferentFormats_WritesEachFormatIntoItsFile(t *testing.T) {
+	//Given
+	outputDir := t.TempDir()
+	finalResult := &finding.Output{
+		Count:   1,
+		Results: []finding.Finding{{ID: "XSSLabel", Severity: rules.SeverityHigh, Occurrence: rules.Occurrence{FileName: "/src/a.page", LineNumber: 4}}},
+	}
+	jsonTarget := options.OutputTarget{Format: options.FormatJSON, Path: filepath.Join(outputDir, "asist.json")}
+	sarifTarget := options.OutputTarget{Format: options.FormatSarif, Path: filepath.Join(outputDir, "asist.sarif")}
+
+	//When
+	writeOutput(jsonTarget, finalResult)
+	writeOutput(sarifTarget, finalResult)
+
+	//Then
+	jsonContent, _ := os.ReadFile(jsonTarget.Path)
+	if !strings.Contains(string(jsonContent), `"Result": [`) {
+		t.Errorf("Expected JSON results, got: %s", jsonContent)
+	}
+	sarifContent, _ := os.ReadFile(sarifTarget.Path)
+	if !strings.Contains(string(sarifContent), `"version": "2.1.0"`) {
+		t.Errorf("Expected a SARIF log, got: %s", sarifContent)
+	}
+}
+
+func TestWriteBaselineScanOutput_WhenTargetsHaveDifferentFormats_WritesBaselineRecordsInJSONOnly(t *testing.T) {
+	//Given
+	outputDir := t.TempDir()
+	finalResult := &finding.Output{
+		Count:   1,
+		Results: []finding.Finding{{ID: "XSSLabel", Severity: rules.SeverityHigh, Occurrence: rules.Occurrence{FileName: "/src/a.page", LineNumber: 4, IsFalsePositive: true}}},
+	}
+	jsonTarget := options.OutputTarget{Format: options.FormatJSON, Path: filepath.Join(outputDir, "asist.json")}
+	sarifTarget := options.OutputTarget{Format: options.FormatSarif, Path: filepath.Join(outputDir, "asist.sarif")}
+
+	//When
+	writeBaselineScanOutput(jsonTarget, finalResult, nil, "git@github.com:certinia/asist.git")
+	writeBaselineScanOutput(sarifTarget, finalResult, nil, "git@github.com:certinia/asist.git")
+
+	//Then
+	jsonContent, _ := os.ReadFile(jsonTarget.Path)
+	if !strings.Contains(string(jsonContent), `"RecordType": "Finding"`) {
+		t.Errorf("Expected baseline records, got: %s", jsonContent)
+	}
+	sarifContent, _ := os.ReadFile(sarifTarget.Path)
+	if !strings.Contains(string(sarifContent), `"version": "2.1.0"`) || !strings.Contains(string(sarifContent), `"ruleId": "XSSLabel"`) {
+		t.Errorf("Expected a SARIF log with the false positive finding, got: %s", sarifContent)
+	}
+}
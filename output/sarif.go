@@ -0,0 +1,270 @@
+package output
+
+import (
+	"net/url"
+	"path/filepath"
+	"sort"
+	"time"
+
+	"github.com/certinia/asist/finding"
+	"github.com/certinia/asist/rules"
+)
+
+const (
+	sarifSchema  = "https://json.schemastore.org/sarif-2.1.0.json"
+	sarifVersion = "2.1.0"
+	toolName     = "ASIST"
+	toolURI      = "https://github.com/certinia/asist"
+)
+
+type sarifLog struct {
+	Schema  string     `json:"$schema"`
+	Version string     `json:"version"`
+	Runs    []sarifRun `json:"runs"`
+}
+
+type sarifRun struct {
+	Tool        sarifTool         `json:"tool"`
+	Invocations []sarifInvocation `json:"invocations,omitempty"`
+	Results     []sarifResult     `json:"results"`
+}
+
+type sarifInvocation struct {
+	ExecutionSuccessful        bool                `json:"executionSuccessful"`
+	ToolExecutionNotifications []sarifNotification `json:"toolExecutionNotifications"`
+}
+
+type sarifNotification struct {
+	Level     string                      `json:"level"`
+	Message   sarifMessage                `json:"message"`
+	Locations []sarifNotificationLocation `json:"locations"`
+}
+
+// sarifNotificationLocation is the location of a notification, which refers to a whole file without region
+type sarifNotificationLocation struct {
+	PhysicalLocation struct {
+		ArtifactLocation sarifArtifactLocation `json:"artifactLocation"`
+	} `json:"physicalLocation"`
+}
+
+type sarifTool struct {
+	Driver sarifDriver `json:"driver"`
+}
+
+type sarifDriver struct {
+	Name           string                     `json:"name"`
+	InformationURI string                     `json:"informationUri"`
+	Rules          []sarifReportingDescriptor `json:"rules"`
+}
+
+type sarifReportingDescriptor struct {
+	ID                   string              `json:"id"`
+	Name                 string              `json:"name"`
+	ShortDescription     sarifMessage        `json:"shortDescription"`
+	FullDescription      sarifMessage        `json:"fullDescription"`
+	DefaultConfiguration sarifConfiguration  `json:"defaultConfiguration"`
+	Properties           sarifRuleProperties `json:"properties"`
+}
+
+type sarifConfiguration struct {
+	Level string `json:"level"`
+}
+
+type sarifRuleProperties struct {
+	Tags     []string `json:"tags"`
+	Severity string   `json:"severity"`
+}
+
+type sarifMessage struct {
+	Text string `json:"text"`
+}
+
+type sarifResult struct {
+	RuleID              string            `json:"ruleId"`
+	RuleIndex           int               `json:"ruleIndex"`
+	Level               string            `json:"level"`
+	Message             sarifMessage      `json:"message"`
+	Locations           []sarifLocation   `json:"locations"`
+	PartialFingerprints map[string]string `json:"partialFingerprints"`
+	BaselineState       string            `json:"baselineState,omitempty"`
+	Properties          map[string]string `json:"properties,omitempty"`
+}
+
+type sarifLocation struct {
+	PhysicalLocation sarifPhysicalLocation `json:"physicalLocation"`
+}
+
+type sarifPhysicalLocation struct {
+	ArtifactLocation sarifArtifactLocation `json:"artifactLocation"`
+	Region           sarifRegion           `json:"region"`
+}
+
+type sarifArtifactLocation struct {
+	URI string `json:"uri"`
+}
+
+type sarifRegion struct {
+	StartLine   int           `json:"startLine"`
+	StartColumn int           `json:"startColumn,omitempty"`
+	EndColumn   int           `json:"endColumn,omitempty"`
+	Snippet     *sarifMessage `json:"snippet,omitempty"`
+}
+
+/**
+ * createSarifOutput - method used to convert the scan result into a SARIF 2.1.0 log
+ */
+func createSarifOutput(finalResult *finding.Output) sarifLog {
+	descriptors := []sarifReportingDescriptor{}
+	ruleIndexes := map[rules.RuleID]int{}
+	results := []sarifResult{}
+
+	// Sort rules by ID so the rule indexes stay stable between runs
+	ruleFindings := map[rules.RuleID]finding.Finding{}
+	for _, result := range finalResult.Results {
+		ruleFindings[result.ID] = result
+	}
+	sortedRuleIds := make([]rules.RuleID, 0, len(ruleFindings))
+	for ruleId := range ruleFindings {
+		sortedRuleIds = append(sortedRuleIds, ruleId)
+	}
+	sort.Slice(sortedRuleIds, func(i, j int) bool {
+		return string(sortedRuleIds[i]) < string(sortedRuleIds[j])
+	})
+	for index, ruleId := range sortedRuleIds {
+		ruleIndexes[ruleId] = index
+		descriptors = append(descriptors, createSarifReportingDescriptor(ruleFindings[ruleId]))
+	}
+
+	for _, result := range finalResult.Results {
+		partialFingerprints := map[string]string{
+			"asistFindingID/v1": result.CreateFindingID(),
+		}
+		if result.Fingerprint != "" {
+			partialFingerprints["asistFingerprint/v1"] = result.Fingerprint
+		}
+		results = append(results, sarifResult{
+			RuleID:    string(result.ID),
+			RuleIndex: ruleIndexes[result.ID],
+			Level:     getSarifLevel(result.Severity),
+			Message:   sarifMessage{Text: result.Name},
+			Locations: []sarifLocation{{
+				PhysicalLocation: sarifPhysicalLocation{
+					ArtifactLocation: sarifArtifactLocation{URI: getSarifURI(result.Occurrence.FileName)},
+					Region:           createSarifRegion(result.Occurrence),
+				},
+			}},
+			PartialFingerprints: partialFingerprints,
+			Properties:          createSarifResultProperties(result),
+		})
+	}
+
+	return sarifLog{
+		Schema:  sarifSchema,
+		Version: sarifVersion,
+		Runs: []sarifRun{{
+			Tool: sarifTool{
+				Driver: sarifDriver{
+					Name:           toolName,
+					InformationURI: toolURI,
+					Rules:          descriptors,
+				},
+			},
+			Invocations: createSarifInvocations(finalResult.Diagnostics),
+			Results:     results,
+		}},
+	}
+}
+
+/**
+ * createSarifResultProperties - method used to add the commit which introduced the line of a finding to its SARIF result, if known
+ */
+func createSarifResultProperties(result finding.Finding) map[string]string {
+	if result.Commit == nil {
+		return nil
+	}
+	return map[string]string{
+		"commit":      result.Commit.Hash,
+		"author":      result.Commit.Author,
+		"authorEmail": result.Commit.Email,
+		"commitDate":  result.Commit.Date.Format(time.RFC3339),
+	}
+}
+
+/**
+ * createSarifInvocations - method used to report the files which could not be fully scanned as tool execution notifications
+ */
+func createSarifInvocations(diagnostics []finding.Diagnostic) []sarifInvocation {
+	if len(diagnostics) == 0 {
+		return nil
+	}
+	notifications := []sarifNotification{}
+	for _, diagnostic := range diagnostics {
+		location := sarifNotificationLocation{}
+		location.PhysicalLocation.ArtifactLocation = sarifArtifactLocation{URI: getSarifURI(diagnostic.FileName)}
+		notifications = append(notifications, sarifNotification{
+			Level:     "warning",
+			Message:   sarifMessage{Text: diagnostic.Message},
+			Locations: []sarifNotificationLocation{location},
+		})
+	}
+	return []sarifInvocation{{ExecutionSuccessful: true, ToolExecutionNotifications: notifications}}
+}
+
+func createSarifReportingDescriptor(result finding.Finding) sarifReportingDescriptor {
+	return sarifReportingDescriptor{
+		ID:                   string(result.ID),
+		Name:                 result.Name,
+		ShortDescription:     sarifMessage{Text: result.Name},
+		FullDescription:      sarifMessage{Text: result.Description},
+		DefaultConfiguration: sarifConfiguration{Level: getSarifLevel(result.Severity)},
+		Properties: sarifRuleProperties{
+			Tags:     []string{string(result.RuleCategory)},
+			Severity: string(result.Severity),
+		},
+	}
+}
+
+/**
+ * createSarifRegion - method used to convert an occurrence into a SARIF region.
+ * SARIF columns are 1-based and the end column is exclusive, ColumnRange is 0-based and end exclusive.
+ */
+func createSarifRegion(occurrence rules.Occurrence) sarifRegion {
+	region := sarifRegion{StartLine: occurrence.LineNumber}
+	// The snippet is left out when the line content is unknown, an empty snippet text would be misleading
+	if occurrence.LineContent != "" {
+		region.Snippet = &sarifMessage{Text: occurrence.LineContent}
+	}
+	if len(occurrence.ColumnRange) == 2 {
+		region.StartColumn = occurrence.ColumnRange[0] + 1
+		region.EndColumn = occurrence.ColumnRange[1] + 1
+	}
+	return region
+}
+
+/**
+ * getSarifLevel - method used to map the rule severity to a SARIF result level
+ */
+func getSarifLevel(severity rules.Severity) string {
+	switch severity {
+	case rules.SeverityCritical, rules.SeverityHigh:
+		return "error"
+	case rules.SeverityMedium:
+		return "warning"
+	default:
+		return "note"
+	}
+}
+
+/**
+ * getSarifURI - method used to convert a file path into a SARIF artifact URI
+ */
+func getSarifURI(fileName string) string {
+	slashPath := filepath.ToSlash(fileName)
+	if !filepath.IsAbs(fileName) {
+		return (&url.URL{Path: slashPath}).String()
+	}
+	if filepath.VolumeName(fileName) != "" {
+		slashPath = "/" + slashPath
+	}
+	return (&url.URL{Scheme: "file", Path: slashPath}).String()
+}
@@ -0,0 +1,121 @@
+package output
+
+import (
+	"encoding/json"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/certinia/asist/finding"
+	"github.com/certinia/asist/rules"
+)
+
+func TestCreateSarifOutput_WhenFindingsExist_ReturnsRulesAndResults(t *testing.T) {
+	//Given
+	finalResult := &finding.Output{
+		Results: []finding.Finding{
+			{ID: "XSSLabel", Name: "XSS Label", Description: "Label description", Severity: rules.SeverityHigh, RuleCategory: rules.CategorySecurity, Occurrence: rules.Occurrence{FileName: "/src/page/visualforce.page", LineContent: "{!$Label.abc}", LineNumber: 4, ColumnRange: []int{2, 12}}},
+			{ID: "ApexClassNoSharing", Name: "Apex Class No Sharing", Severity: rules.SeverityMedium, RuleCategory: rules.CategorySecurity, Occurrence: rules.Occurrence{FileName: "/src/class/sampleClass.cls", LineNumber: 1}},
+			{ID: "XSSLabel", Name: "XSS Label", Severity: rules.SeverityHigh, RuleCategory: rules.CategorySecurity, Occurrence: rules.Occurrence{FileName: "/src/page/visualforce.page", LineNumber: 9, ColumnRange: []int{0, 5}}},
+		},
+	}
+
+	//When
+	actualResult := createSarifOutput(finalResult)
+
+	//Then
+	if actualResult.Version != "2.1.0" || len(actualResult.Runs) != 1 {
+		t.Fatalf("Expected a single SARIF 2.1.0 run, got: %+v", actualResult)
+	}
+	run := actualResult.Runs[0]
+	actualRuleIds := []string{}
+	for _, descriptor := range run.Tool.Driver.Rules {
+		actualRuleIds = append(actualRuleIds, descriptor.ID)
+	}
+	if !reflect.DeepEqual(actualRuleIds, []string{"ApexClassNoSharing", "XSSLabel"}) {
+		t.Errorf("Rules should be unique and sorted by ID. Actual: %v", actualRuleIds)
+	}
+	if !reflect.DeepEqual(run.Tool.Driver.Rules[1].Properties.Tags, []string{"Security"}) {
+		t.Errorf("Rule category should be added as tag. Actual: %v", run.Tool.Driver.Rules[1].Properties.Tags)
+	}
+	if len(run.Results) != 3 {
+		t.Fatalf("Expected 3 results, got: %d", len(run.Results))
+	}
+	if run.Results[0].RuleIndex != 1 || run.Results[1].RuleIndex != 0 || run.Results[0].Level != "error" || run.Results[1].Level != "warning" {
+		t.Errorf("Result rule index or level mismatched. Actual: %+v", run.Results)
+	}
+	expectedRegion := sarifRegion{StartLine: 4, StartColumn: 3, EndColumn: 13, Snippet: &sarifMessage{Text: "{!$Label.abc}"}}
+	if !reflect.DeepEqual(run.Results[0].Locations[0].PhysicalLocation.Region, expectedRegion) {
+		t.Errorf("Region mismatched. Actual: %+v, Expected: %+v", run.Results[0].Locations[0].PhysicalLocation.Region, expectedRegion)
+	}
+	if run.Results[0].Locations[0].PhysicalLocation.ArtifactLocation.URI != "file:///src/page/visualforce.page" {
+		t.Errorf("URI mismatched. Actual: %s", run.Results[0].Locations[0].PhysicalLocation.ArtifactLocation.URI)
+	}
+}
+
+func TestCreateSarifRegion_WhenLineContentIsEmpty_OmitsSnippet(t *testing.T) {
+	//Given
+	occurrence := rules.Occurrence{FileName: "/src/a.cls", LineNumber: 1}
+
+	//When
+	jsonOutput, err := json.Marshal(createSarifRegion(occurrence))
+
+	//Then
+	if err != nil {
+		t.Fatalf("Should not return error while marshalling: %v", err)
+	}
+	if strings.Contains(string(jsonOutput), "snippet") {
+		t.Errorf("Expected no snippet. Actual: %s", jsonOutput)
+	}
+}
+
+func TestCreateSarifOutput_WhenNoFindings_ReturnsEmptyArrays(t *testing.T) {
+	//Given
+	finalResult := &finding.Output{Results: []finding.Finding{}}
+
+	//When
+	jsonOutput, err := json.Marshal(createSarifOutput(finalResult))
+
+	//Then
+	if err != nil {
+		t.Fatalf("Should not return error while marshalling: %v", err)
+	}
+	expected := `{"$schema":"https://json.schemastore.org/sarif-2.1.0.json","version":"2.1.0","runs":[{"tool":{"driver":{"name":"ASIST","informationUri":"https://github.com/certinia/asist","rules":[]}},"results":[]}]}`
+	if string(jsonOutput) != expected {
+		t.Errorf("SARIF output mismatched.\n Actual %s\n Expected %s", jsonOutput, expected)
+	}
+}
+
+func TestGetSarifLevel(t *testing.T) {
+	testCases := map[rules.Severity]string{
+		rules.SeverityCritical: "error",
+		rules.SeverityHigh:     "error",
+		rules.SeverityMedium:   "warning",
+		rules.SeverityLow:      "note",
+	}
+	for severity, expected := range testCases {
+		if actual := getSarifLevel(severity); actual != expected {
+			t.Errorf("Level mismatched for %s. Actual: %s, Expected: %s", severity, actual, expected)
+		}
+	}
+}
+
+func TestCreateSarifOutput_WhenDiagnosticsExist_ReturnsToolExecutionNotifications(t *testing.T) {
+	//Given
+	finalResult := &finding.Output{
+		Results:     []finding.Finding{},
+		Diagnostics: []finding.Diagnostic{{FileName: "src/Generated.cls", Status: finding.DiagnosticStatusSkipped, Reason: finding.DiagnosticReasonTimeout, Message: "Scan timed out"}},
+	}
+
+	//When
+	run := createSarifOutput(finalResult).Runs[0]
+
+	//Then
+	if len(run.Invocations) != 1 || len(run.Invocations[0].ToolExecutionNotifications) != 1 {
+		t.Fatalf("Expected a single notification. Actual: %+v", run.Invocations)
+	}
+	notification := run.Invocations[0].ToolExecutionNotifications[0]
+	if notification.Message.Text != "Scan timed out" || notification.Locations[0].PhysicalLocation.ArtifactLocation.URI != "src/Generated.cls" {
+		t.Errorf("Notification mismatched. Actual: %+v", notification)
+	}
+}
@@ -0,0 +1,183 @@
+package output
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"strings"
+	"time"
+	"unicode/utf8"
+
+	"github.com/certinia/asist/finding"
+	"github.com/certinia/asist/message"
+	"github.com/certinia/asist/rules"
+	"github.com/certinia/asist/utils"
+)
+
+// shortHashLength is the number of characters of the commit hashes shown in the text output
+const shortHashLength = 10
+
+// maxSnippetLength is the maximum number of characters of a line shown in the text output, around the column range
+const maxSnippetLength = 160
+
+/**
+ * writeTextOutput - method used to write the findings for a human reader, one finding per line followed by the line content
+ *	with a caret under the column range, and a summary of the findings per severity.
+ *	When groupBy is set, the findings are written under a header per author or age of their commit.
+ */
+func writeTextOutput(w io.Writer, finalResult *finding.Output, useColors bool, groupBy string) {
+	colorize := createTextColorizer(useColors)
+
+	findingsPerSeverity := map[rules.Severity][]finding.Finding{}
+	for _, result := range finalResult.Results {
+		findingsPerSeverity[result.Severity] = append(findingsPerSeverity[result.Severity], result)
+	}
+	if groupBy == "" {
+		for _, result := range finalResult.Results {
+			writeTextFinding(w, result, "", true, colorize)
+		}
+	} else {
+		groups, findingsPerGroup := groupFindings(finalResult.Results, groupBy, time.Now())
+		for index, group := range groups {
+			if index > 0 {
+				fmt.Fprintln(w)
+			}
+			fmt.Fprintf(w, "%s\n", colorize(message.Info, message.GetTextGroupHeader(group, len(findingsPerGroup[group]))))
+			for _, result := range findingsPerGroup[group] {
+				writeTextFinding(w, result, "", true, colorize)
+			}
+		}
+	}
+
+	summary := []string{}
+	for _, severity := range getSortedSeverities(findingsPerSeverity) {
+		summary = append(summary, colorize(getSeverityLogType(severity), fmt.Sprintf("%d %s", len(findingsPerSeverity[severity]), severity)))
+	}
+	if len(summary) == 0 {
+		fmt.Fprintf(w, "%s\n", colorize(message.Info, message.GetNoFindingsSummary()))
+		return
+	}
+	fmt.Fprintf(w, "\n%s\n", message.GetTextSummary(len(finalResult.Results), strings.Join(summary, ", ")))
+}
+
+/**
+ * isColorTerminal - method used to check if colors can be printed, only in a terminal and never into files or pipes
+ */
+func isColorTerminal(w io.Writer) bool {
+	return w == os.Stdout && utils.IsTerminal(os.Stdout) && os.Getenv("NO_COLOR") == ""
+}
+
+/**
+ * createTextColorizer - method used to create the function coloring a text by log type, which keeps the text as is without colors
+ */
+func createTextColorizer(useColors bool) func(logType string, text string) string {
+	return func(logType string, text string) string {
+		if !useColors {
+			return text
+		}
+		return message.TextColor[logType] + text + message.TextColor[message.Reset]
+	}
+}
+
+/**
+ * writeTextFinding - method used to write the location, severity and rule of a finding after the prefix,
+ *	followed by the line content with a caret under the column range if requested
+ */
+func writeTextFinding(w io.Writer, result finding.Finding, prefix string, withSnippet bool, colorize func(logType string, text string) string) {
+	location := fmt.Sprintf("%s:%d:", result.Occurrence.FileName, result.Occurrence.LineNumber)
+	if len(result.Occurrence.ColumnRange) == 2 {
+		location = fmt.Sprintf("%s:%d:%d:", result.Occurrence.FileName, result.Occurrence.LineNumber, result.Occurrence.ColumnRange[0]+1)
+	}
+	fmt.Fprintf(w, "%s%s %s %s %s\n", prefix, location, colorize(getSeverityLogType(result.Severity), string(result.Severity)), result.ID, result.Name)
+	if result.Commit != nil {
+		fmt.Fprintf(w, "    %s\n", message.GetFindingCommitInfo(getShortHash(result.Commit.Hash), result.Commit.Author, result.Commit.Email, result.Commit.Date.Format(time.DateOnly)))
+	}
+	if !withSnippet {
+		return
+	}
+	snippet, caret := createTextSnippet(result.Occurrence)
+	if strings.TrimSpace(snippet) != "" {
+		fmt.Fprintf(w, "    %s\n", snippet)
+		if caret != "" {
+			fmt.Fprintf(w, "    %s\n", colorize(message.Error, caret))
+		}
+	}
+}
+
+/**
+ * createTextSnippet - method used to get the line content of an occurrence with a caret line under its column range.
+ *	Long lines are shortened around the column range and tabs are kept in the caret line so the caret stays aligned.
+ */
+func createTextSnippet(occurrence rules.Occurrence) (string, string) {
+	lineContent := occurrence.LineContent
+	columnRange := occurrence.ColumnRange
+	// ColumnRange is 0-based and end exclusive, ignore the ranges which do not fit the line content
+	hasColumnRange := len(columnRange) == 2 && columnRange[0] >= 0 && columnRange[0] < columnRange[1] && columnRange[1] <= len(lineContent)
+	if !hasColumnRange {
+		shortLineContent, suffix := keepFirstRunes(strings.TrimRight(lineContent, " \t"), maxSnippetLength)
+		return shortLineContent + suffix, ""
+	}
+	before, highlight, after := lineContent[:columnRange[0]], lineContent[columnRange[0]:columnRange[1]], lineContent[columnRange[1]:]
+	prefix, suffix := "", ""
+	// Keep the end of the text before the column range and the start of the text after it
+	if utf8.RuneCountInString(before)+utf8.RuneCountInString(highlight)+utf8.RuneCountInString(after) > maxSnippetLength {
+		before, prefix = keepLastRunes(before, maxSnippetLength/4)
+		after, suffix = keepFirstRunes(after, maxSnippetLength/4)
+		highlight, _ = keepFirstRunes(highlight, maxSnippetLength/2)
+	}
+	var caret strings.Builder
+	for _, char := range prefix + before {
+		if char == '\t' {
+			caret.WriteRune('\t')
+		} else {
+			caret.WriteRune(' ')
+		}
+	}
+	caret.WriteString(strings.Repeat("^", max(utf8.RuneCountInString(highlight), 1)))
+	return prefix + before + highlight + after + suffix, caret.String()
+}
+
+/**
+ * keepFirstRunes - method used to keep the first characters of a text, the suffix is an ellipsis when the text is shortened
+ */
+func keepFirstRunes(text string, runeCount int) (string, string) {
+	if utf8.RuneCountInString(text) <= runeCount {
+		return text, ""
+	}
+	return string([]rune(text)[:runeCount]), "…"
+}
+
+/**
+ * keepLastRunes - method used to keep the last characters of a text, the prefix is an ellipsis when the text is shortened
+ */
+func keepLastRunes(text string, runeCount int) (string, string) {
+	runes := []rune(text)
+	if len(runes) <= runeCount {
+		return text, ""
+	}
+	return string(runes[len(runes)-runeCount:]), "…"
+}
+
+/**
+ * getSeverityLogType - method used to get the color of a severity in the text output
+ */
+func getSeverityLogType(severity rules.Severity) string {
+	switch severity {
+	case rules.SeverityCritical, rules.SeverityHigh:
+		return message.Error
+	case rules.SeverityMedium:
+		return message.Warning
+	default:
+		return message.Debug
+	}
+}
+
+/**
+ * getShortHash - method used to shorten a commit hash, like git log --oneline
+ */
+func getShortHash(hash string) string {
+	if len(hash) > shortHashLength {
+		return hash[:shortHashLength]
+	}
+	return hash
+}
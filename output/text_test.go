@@ -0,0 +1,89 @@
+package output
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/certinia/asist/finding"
+	"github.com/certinia/asist/rules"
+)
+
+func TestWriteTextOutput_WhenFindingsExist_PrintsLocationCaretAndSummary(t *testing.T) {
+	//Given
+	finalResult := &finding.Output{
+		Results: []finding.Finding{
+			{ID: "XSSLabel", Name: "XSS Label", Severity: rules.SeverityHigh, Occurrence: rules.Occurrence{FileName: "/src/a.page", LineNumber: 4, LineContent: "\t<b>{!$Label.abc}</b>", ColumnRange: []int{4, 17}}},
+			{ID: "ApexClassNoSharing", Name: "Apex Class No Sharing", Severity: rules.SeverityMedium, Occurrence: rules.Occurrence{FileName: "/src/a.cls", LineNumber: 1, LineContent: "public class A {"}},
+		},
+	}
+	var buf bytes.Buffer
+
+	//When
+	writeTextOutput(&buf, finalResult, false, "")
+
+	//Then
+	expected := "/src/a.page:4:5: High XSSLabel XSS Label\n" +
+		"    \t<b>{!$Label.abc}</b>\n" +
+		"    \t   ^^^^^^^^^^^^^\n" +
+		"/src/a.cls:1: Medium ApexClassNoSharing Apex Class No Sharing\n" +
+		"    public class A {\n" +
+		"\n2 finding(s): 1 High, 1 Medium\n"
+	if buf.String() != expected {
+		t.Errorf("Text output mismatched.\n Actual %q\n Expected %q", buf.String(), expected)
+	}
+}
+
+func TestWriteTextOutput_WhenFindingHasCommit_PrintsCommitUnderLocation(t *testing.T) {
+	//Given
+	commit := &finding.Commit{Hash: "0123456789abcdef", Author: "Jane Doe", Email: "jane@example.com", Date: time.Date(2024, 5, 1, 10, 0, 0, 0, time.UTC)}
+	finalResult := &finding.Output{
+		Results: []finding.Finding{
+			{ID: "HardcodedCredentials", Name: "Hardcoded Credentials", Severity: rules.SeverityMedium, Occurrence: rules.Occurrence{FileName: "/src/a.cls", LineNumber: 2, LineContent: "String password = 'x';"}, Commit: commit},
+		},
+	}
+	var buf bytes.Buffer
+
+	//When
+	writeTextOutput(&buf, finalResult, false, "")
+
+	//Then
+	expected := "/src/a.cls:2: Medium HardcodedCredentials Hardcoded Credentials\n" +
+		"    introduced in 0123456789 by Jane Doe <jane@example.com> on 2024-05-01\n" +
+		"    String password = 'x';\n" +
+		"\n1 finding(s): 1 Medium\n"
+	if buf.String() != expected {
+		t.Errorf("Text output mismatched.\n Actual %q\n Expected %q", buf.String(), expected)
+	}
+}
+
+func TestWriteTextOutput_WhenNoFindings_PrintsNoFindings(t *testing.T) {
+	//Given
+	var buf bytes.Buffer
+
+	//When
+	writeTextOutput(&buf, &finding.Output{Results: []finding.Finding{}}, false, "")
+
+	//Then
+	if buf.String() != "No findings\n" {
+		t.Errorf("Text output mismatched. Actual %q", buf.String())
+	}
+}
+
+func TestCreateTextSnippet_WhenLineIsLong_ShortensLineAroundColumnRange(t *testing.T) {
+	//Given
+	occurrence := rules.Occurrence{LineContent: strings.Repeat("a", 500) + "location.search" + strings.Repeat("b", 500), ColumnRange: []int{500, 515}}
+
+	//When
+	snippet, caret := createTextSnippet(occurrence)
+
+	//Then
+	expectedSnippet := "…" + strings.Repeat("a", 40) + "location.search" + strings.Repeat("b", 40) + "…"
+	if snippet != expectedSnippet {
+		t.Errorf("Snippet mismatched.\n Actual %q\n Expected %q", snippet, expectedSnippet)
+	}
+	if caret != strings.Repeat(" ", 41)+strings.Repeat("^", 15) {
+		t.Errorf("Caret should stay under the column range. Actual %q", caret)
+	}
+}
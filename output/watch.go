@@ -0,0 +1,32 @@
+package output
+
+import (
+	"fmt"
+	"io"
+	"time"
+
+	"github.com/certinia/asist/finding"
+	"github.com/certinia/asist/message"
+)
+
+/**
+ * DisplayTextOutput - method used to write the findings in the text format, e.g. the initial scan of the watch mode
+ */
+func DisplayTextOutput(w io.Writer, finalResult *finding.Output) {
+	writeTextOutput(w, finalResult, isColorTerminal(w), "")
+}
+
+/**
+ * DisplayWatchChanges - method used to write the findings added and resolved by changes of files in the text format.
+ *	Added findings are prefixed with + and shown with their line content, resolved findings are prefixed with -.
+ */
+func DisplayWatchChanges(w io.Writer, changeTime time.Time, added []finding.Finding, resolved []finding.Finding) {
+	colorize := createTextColorizer(isColorTerminal(w))
+	fmt.Fprintf(w, "\n%s\n", colorize(message.Info, message.GetWatchChangesSummary(changeTime.Format(time.TimeOnly), len(added), len(resolved))))
+	for _, result := range added {
+		writeTextFinding(w, result, "+ ", true, colorize)
+	}
+	for _, result := range resolved {
+		writeTextFinding(w, result, "- ", false, colorize)
+	}
+}
@@ -4,30 +4,81 @@ import (
 	"os"
 	"path/filepath"
 	"strings"
+	"time"
 
 	"github.com/certinia/asist/debugger"
 	"github.com/certinia/asist/errorhandler"
 	"github.com/certinia/asist/message"
 	"github.com/certinia/asist/rules"
+	"github.com/certinia/asist/utils"
 	"github.com/jessevdk/go-flags"
 )
 
+const (
+	FormatJSON        = "json"
+	FormatSarif       = "sarif"
+	FormatJUnit       = "junit"
+	FormatHTML        = "html"
+	FormatText        = "text"
+	FormatGitlabSast  = "gitlab-sast"
+	FormatCodeClimate = "codeclimate"
+	FormatCheckstyle  = "checkstyle"
+	FormatGithub      = "github"
+	FormatCSV         = "csv"
+	FormatMarkdown    = "markdown"
+)
+
+const (
+	GroupByAuthor = "author"
+	GroupByAge    = "age"
+)
+
 type Options struct {
-	RepoURL      string `short:"u" long:"repo-url" required:"false" description:"URL of the repo. Used for baseline scan output"`
-	ConfigFile   string `short:"c" long:"config" required:"false" description:"JSON or YAML config file to read from"`
-	Rules        string `short:"r" long:"rules" required:"false" description:"Rules comma separated to run (ignore rules enabled/disabled in config)"`
-	ListRules    bool   `short:"l" long:"list-rules" required:"false" description:"List rules which would be run"`
-	BaselineScan bool   `short:"b" long:"baseline-scan" required:"false" description:"For getting output of ASIST baseline scan as count of occurrences and false positive occurrences, number of custom rules occurrences, type of record and this data is used for creating metrics."`
-	CICDScan     bool   `short:"j" long:"cicd-rules" required:"false" description:"For use in CI/CD pipelines. Tells ASIST to only run the CICD rules defined in config file. If there are no CI/CD rules defined, no rule will run. If there are any occurrences, returns a non-zero exit code which will make the pipeline step fail"`
-	Debug        bool   `short:"v" long:"verbose" required:"false" description:"Print out debug messages with time elapsed since last message"`
-	Version      bool   `short:"V" long:"version" required:"false" description:"Display the current version of ASIST binary"`
+	RepoURL        string        `short:"u" long:"repo-url" required:"false" description:"URL of the repo. Used for baseline scan output"`
+	ConfigFile     string        `short:"c" long:"config" required:"false" description:"JSON or YAML config file to read from"`
+	Rules          string        `short:"r" long:"rules" required:"false" description:"Rules comma separated to run (ignore rules enabled/disabled in config)"`
+	ListRules      bool          `short:"l" long:"list-rules" required:"false" description:"List rules which would be run"`
+	BaselineScan   bool          `short:"b" long:"baseline-scan" required:"false" description:"For getting output of ASIST baseline scan as count of occurrences and false positive occurrences, number of custom rules occurrences, type of record and this data is used for creating metrics."`
+	CICDScan       bool          `short:"j" long:"cicd-rules" required:"false" description:"For use in CI/CD pipelines. Tells ASIST to only run the CICD rules defined in config file. If there are no CI/CD rules defined, no rule will run. If there are any occurrences, returns a non-zero exit code which will make the pipeline step fail"`
+	Debug          bool          `short:"v" long:"verbose" required:"false" description:"Print out debug messages with time elapsed since last message"`
+	Version        bool          `short:"V" long:"version" required:"false" description:"Display the current version of ASIST binary"`
+	Format         []string      `short:"f" long:"format" required:"false" choice:"json" choice:"sarif" choice:"junit" choice:"html" choice:"text" choice:"gitlab-sast" choice:"codeclimate" choice:"checkstyle" choice:"github" choice:"csv" choice:"markdown" description:"Output format of the scan results, text when the standard output is a terminal and json otherwise (default). Repeat with --output to write several formats, the nth format is written into the nth output"`
+	Output         []string      `short:"o" long:"output" required:"false" description:"Write the scan results into this file instead of the standard output (- for the standard output). Can be repeated, one per --format"`
+	Jobs           int           `long:"jobs" required:"false" description:"Number of files to scan in parallel (defaults to the number of CPUs)"`
+	Baseline       string        `long:"baseline" required:"false" description:"Baseline file of existing findings. Only findings which are not in the baseline count towards the CI/CD thresholds"`
+	WriteBaseline  string        `long:"write-baseline" required:"false" description:"Write the findings of this scan into a baseline file"`
+	Since          string        `long:"since" required:"false" description:"Only scan the files and lines changed in the working tree since the given git ref (branch, tag or commit)"`
+	Stdin          bool          `long:"stdin" required:"false" description:"Scan the content read from the standard input instead of a path. Requires --stdin-filename"`
+	StdinFilename  string        `long:"stdin-filename" required:"false" description:"Virtual file name of the content read from the standard input, used to select the rules to run (e.g. force-app/main/default/classes/Foo.cls)"`
+	MaxLineLength  int           `long:"max-line-length" required:"false" description:"Maximum length in bytes of a line to scan, longer lines are truncated and reported as diagnostics (no limit by default)"`
+	ChunkLongLines bool          `long:"chunk-long-lines" required:"false" description:"Split the lines longer than --max-line-length into chunks which are all scanned, instead of truncating them"`
+	FileTimeout    time.Duration `long:"file-timeout" required:"false" description:"Maximum time spent scanning a single file (e.g. 30s). The rules left to run on a file which timed out are skipped and reported as diagnostics"`
+	Blame          bool          `long:"blame" required:"false" description:"Add the commit, author and date which last modified the line of each finding, from git blame"`
+	GroupBy        string        `long:"group-by" required:"false" choice:"author" choice:"age" description:"Group the findings of the text and markdown formats by the author or the age of their commit. Requires --blame, except for asist history"`
+	CacheDir       string        `long:"cache-dir" required:"false" description:"Directory of the cache of the findings per file (e.g. .asist-cache), the files which did not change since the previous scan are not scanned again. The cache is invalidated when the binary version, the rules or the config change"`
 
+	// Path is read from the arguments left after parsing, as positional arguments would prevent subcommands from being parsed
 	Args struct {
-		Path string `description:"Path to the file or folder to scan"`
-	} `positional-args:"yes"`
+		Path string
+	} `no-flag:"true"`
+}
+
+// OutputTarget is a format of the scan results and the file it is written into, the standard output when Path is empty or -
+type OutputTarget struct {
+	Format string
+	Path   string
+}
+
+// Command is a subcommand of ASIST (e.g. asist lsp), executed instead of a scan
+type Command struct {
+	Name             string
+	ShortDescription string
+	LongDescription  string
+	Data             flags.Commander
 }
 
 var opts Options
+var commands []Command
 
 func GetRepoURL() string {
 	return opts.RepoURL
@@ -36,13 +87,62 @@ func IsCICDScan() bool {
 	return opts.CICDScan
 }
 
+/**
+ * AddCommand - method used to register a subcommand, must be called before Initilize
+ */
+func AddCommand(command Command) {
+	commands = append(commands, command)
+}
+
+/**
+ * Initilize - method used to parse the command line options.
+ *	If a subcommand is given, it is executed and the process exits once it returns.
+ */
 func Initilize() *Options {
-	_, err := flags.Parse(&opts)
+	var activeCommand flags.Commander
+	var commandArgs []string
+
+	parser := flags.NewParser(&opts, flags.Default)
+	parser.Usage = "[OPTIONS] [Path]"
+	parser.LongDescription = message.GetSubcommandPathHelp()
+	parser.SubcommandsOptional = true
+	// Defer the execution of the subcommand until the options are set up
+	parser.CommandHandler = func(command flags.Commander, args []string) error {
+		activeCommand = command
+		commandArgs = args
+		return nil
+	}
+	for _, command := range commands {
+		if _, err := parser.AddCommand(command.Name, command.ShortDescription, command.LongDescription, command.Data); err != nil {
+			errorhandler.ExitWithCode(err.Error(), errorhandler.ExitCodeInternalError)
+		}
+	}
+	args, err := parser.Parse()
 	if err != nil {
 		os.Exit(int(errorhandler.ExitCodeUserError))
 	}
-	validation()
 	setup()
+	if activeCommand != nil {
+		if err := activeCommand.Execute(commandArgs); err != nil {
+			errorhandler.ExitWithError(err)
+		}
+		os.Exit(int(errorhandler.ExitCodeSuccess))
+	}
+	if len(args) > 1 {
+		errorhandler.ExitWithCode(message.GetTooManyPathsError(args), errorhandler.ExitCodeUserError)
+	}
+	if len(args) > 0 {
+		opts.Args.Path = args[0]
+	}
+	setDefaultFormat()
+	validation()
+	return &opts
+}
+
+/**
+ * GetOptions - method used by subcommands to get the global options
+ */
+func GetOptions() *Options {
 	return &opts
 }
 
@@ -71,6 +171,53 @@ func IsVersion() bool {
 	return opts.Version
 }
 
+/**
+ * GetOutputTargets - method used to get the formats of the scan results with the files they are written into,
+ *	the nth format is written into the nth output
+ */
+func GetOutputTargets() []OutputTarget {
+	if len(opts.Output) == 0 {
+		return []OutputTarget{{Format: opts.Format[0]}}
+	}
+	outputTargets := []OutputTarget{}
+	for index, path := range opts.Output {
+		outputTargets = append(outputTargets, OutputTarget{Format: opts.Format[index], Path: path})
+	}
+	return outputTargets
+}
+
+func GetBaseline() string {
+	return opts.Baseline
+}
+
+func GetWriteBaseline() string {
+	return opts.WriteBaseline
+}
+
+func GetCacheDir() string {
+	return opts.CacheDir
+}
+
+func IsBlame() bool {
+	return opts.Blame
+}
+
+func GetGroupBy() string {
+	return opts.GroupBy
+}
+
+func GetSince() string {
+	return opts.Since
+}
+
+func IsStdin() bool {
+	return opts.Stdin
+}
+
+func GetStdinFilename() string {
+	return opts.StdinFilename
+}
+
 func (o *Options) SpecificRuleIds() []rules.RuleID {
 	ruleIds := []rules.RuleID{}
 
@@ -86,8 +233,62 @@ func setup() {
 	}
 }
 
+/**
+ * setDefaultFormat - method used to print the results as text in a terminal and as json otherwise, when no format is given
+ */
+func setDefaultFormat() {
+	if len(opts.Format) > 0 {
+		return
+	}
+	// The records of baseline scans are JSON, unless another format is given
+	if len(opts.Output) == 0 && utils.IsTerminal(os.Stdout) && !opts.BaselineScan {
+		opts.Format = []string{FormatText}
+	} else {
+		opts.Format = []string{FormatJSON}
+	}
+}
+
+/**
+ * SetupOutputTargets - method used by subcommands which write results to set the default format and validate the outputs
+ */
+func SetupOutputTargets() error {
+	setDefaultFormat()
+	return validateOutputTargets()
+}
+
+/**
+ * validateOutputTargets - method used to check there is one format per output
+ */
+func validateOutputTargets() error {
+	if len(opts.Format) != max(len(opts.Output), 1) {
+		return errorhandler.NewUserError(message.GetOutputFormatMismatchError(len(opts.Format), len(opts.Output)))
+	}
+	return nil
+}
+
 func validation() {
-	if len(opts.Args.Path) == 0 && !opts.ListRules && !opts.Version {
+	if opts.Stdin && len(opts.StdinFilename) == 0 {
+		errorhandler.ExitWithCode(message.GetMissingStdinFilenameError(), errorhandler.ExitCodeUserError)
+	}
+	if opts.ChunkLongLines && opts.MaxLineLength <= 0 {
+		errorhandler.ExitWithCode(message.GetMissingMaxLineLengthError(), errorhandler.ExitCodeUserError)
+	}
+	if opts.Blame && opts.Stdin {
+		errorhandler.ExitWithCode(message.GetBlameStdinError(), errorhandler.ExitCodeUserError)
+	}
+	if opts.Since != "" && opts.Stdin {
+		errorhandler.ExitWithCode(message.GetSinceStdinError(), errorhandler.ExitCodeUserError)
+	}
+	if len(opts.Args.Path) > 0 && opts.Stdin {
+		errorhandler.ExitWithCode(message.GetPathStdinError(opts.Args.Path), errorhandler.ExitCodeUserError)
+	}
+	if opts.GroupBy != "" && !opts.Blame {
+		errorhandler.ExitWithCode(message.GetMissingBlameError(), errorhandler.ExitCodeUserError)
+	}
+	if err := validateOutputTargets(); err != nil {
+		errorhandler.ExitWithError(err)
+	}
+	if len(opts.Args.Path) == 0 && !opts.ListRules && !opts.Version && !opts.Stdin {
 		errorhandler.ExitWithCode(message.GetMissingFileOrFolderError(), errorhandler.ExitCodeUserError)
 	}
 }
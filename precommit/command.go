@@ -0,0 +1,71 @@
+package precommit
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"os/signal"
+
+	"github.com/certinia/asist/config"
+	"github.com/certinia/asist/errorhandler"
+	"github.com/certinia/asist/files"
+	"github.com/certinia/asist/message"
+	"github.com/certinia/asist/output"
+	"github.com/certinia/asist/parser/options"
+	"github.com/certinia/asist/scanner"
+)
+
+// Command is the asist pre-commit subcommand, run by the git pre-commit hook
+type Command struct{}
+
+/**
+ * Execute - method used to scan the staged content with the CI/CD rules and to block the commit when a rule exceeds its threshold
+ */
+func (c *Command) Execute(args []string) error {
+	opts := options.GetOptions()
+	configFilePath := opts.ConfigFile
+	if repositoryRoot := files.GetRepositoryRoot("."); configFilePath == "" && repositoryRoot != "" {
+		configFilePath = config.FindConfigFile(repositoryRoot)
+	}
+	configFile, err := config.Load(configFilePath)
+	if err != nil {
+		return err
+	}
+	scanOptions := scanner.NewScanOptions(opts)
+	scanOptions.CICDScan = true
+
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt)
+	defer stop()
+	finalResult, err := ScanStagedFiles(ctx, ".", configFile, scanOptions)
+	if err != nil {
+		return err
+	}
+	output.ReportDiagnostics(os.Stderr, finalResult.Diagnostics)
+	if len(finalResult.Results) == 0 {
+		return nil
+	}
+	output.DisplayTextOutput(os.Stdout, finalResult)
+	if output.CheckThresholdViolations(os.Stderr, finalResult, configFile) {
+		fmt.Fprintf(os.Stderr, "\n%s\n", message.GetCommitBlockedError())
+		os.Exit(int(errorhandler.ExitCodeOccurrence))
+	}
+	return nil
+}
+
+// InstallHookCommand is the asist install-hook subcommand, it writes the git pre-commit hook running asist pre-commit
+type InstallHookCommand struct {
+	Force bool `long:"force" description:"Overwrite an existing pre-commit hook which was not installed by ASIST"`
+}
+
+/**
+ * Execute - method used to install the pre-commit hook into the repository of the current directory,
+ *	the hook uses the config file given with -c
+ */
+func (c *InstallHookCommand) Execute(args []string) error {
+	hookPath, err := InstallHook(".", options.GetOptions().ConfigFile, c.Force)
+	if err != nil {
+		return err
+	}
+	fmt.Fprintf(os.Stderr, "%s\n", message.GetHookInstalledInfo(hookPath))
+	return nil
+}
@@ -0,0 +1,103 @@
+package precommit
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/go-git/go-git/v5"
+	"github.com/go-git/go-git/v5/storage/filesystem"
+
+	"github.com/certinia/asist/errorhandler"
+	"github.com/certinia/asist/message"
+)
+
+// hookMarker identifies the hooks written by install-hook, which can be overwritten
+const hookMarker = "# Installed by asist install-hook"
+
+/**
+ * InstallHook - method used to write the pre-commit hook running asist pre-commit into the repository containing the root path.
+ *	A relative config file path is relative to the root path. The core.hooksPath setting is honoured.
+ *	An existing hook which was not installed by ASIST is only overwritten with force.
+ */
+func InstallHook(rootPath string, configFilePath string, force bool) (string, error) {
+	hooksDir, worktreeRoot, err := getHooksDir(rootPath)
+	if err != nil {
+		return "", err
+	}
+	if configFilePath != "" {
+		configFilePath = getHookConfigFilePath(rootPath, worktreeRoot, configFilePath)
+	}
+	hookPath := filepath.Join(hooksDir, "pre-commit")
+	if existingHook, err := os.ReadFile(hookPath); err == nil && !force && !strings.Contains(string(existingHook), hookMarker) {
+		return "", errorhandler.NewUserError(message.GetExistingHookError(hookPath))
+	}
+	if err := os.MkdirAll(hooksDir, 0755); err != nil {
+		return "", errorhandler.NewUserError(message.GetHookWriteError(hookPath, err))
+	}
+	if err := os.WriteFile(hookPath, []byte(createHookScript(configFilePath)), 0755); err != nil {
+		return "", errorhandler.NewUserError(message.GetHookWriteError(hookPath, err))
+	}
+	return hookPath, nil
+}
+
+/**
+ * getHookConfigFilePath - method used to get the path of the config file as seen by the hook, which git runs from the root of the
+ *	working tree. A config file inside the working tree is kept relative so the hook still works once the repository is moved.
+ */
+func getHookConfigFilePath(rootPath string, worktreeRoot string, configFilePath string) string {
+	if !filepath.IsAbs(configFilePath) {
+		configFilePath = filepath.Join(rootPath, configFilePath)
+	}
+	absConfigFilePath, err := filepath.Abs(configFilePath)
+	if err != nil {
+		return configFilePath
+	}
+	relConfigFilePath, err := filepath.Rel(worktreeRoot, absConfigFilePath)
+	if err != nil || relConfigFilePath == ".." || strings.HasPrefix(relConfigFilePath, ".."+string(filepath.Separator)) {
+		return absConfigFilePath
+	}
+	return relConfigFilePath
+}
+
+/**
+ * createHookScript - method used to create the shell script of the pre-commit hook, with the config file if given
+ */
+func createHookScript(configFilePath string) string {
+	command := "asist pre-commit"
+	if configFilePath != "" {
+		command = "asist -c '" + strings.ReplaceAll(filepath.ToSlash(configFilePath), "'", `'\''`) + "' pre-commit"
+	}
+	return "#!/bin/sh\n" + hookMarker + "\n" + "exec " + command + "\n"
+}
+
+/**
+ * getHooksDir - method used to get the hooks directory of a repository, core.hooksPath or the hooks folder of the git directory,
+ *	and the root of its working tree
+ */
+func getHooksDir(rootPath string) (string, string, error) {
+	repository, err := git.PlainOpenWithOptions(rootPath, &git.PlainOpenOptions{DetectDotGit: true})
+	if err != nil {
+		return "", "", errorhandler.NewUserError(message.GetGitRepositoryError(rootPath, err))
+	}
+	worktree, err := repository.Worktree()
+	if err != nil {
+		return "", "", errorhandler.NewUserError(message.GetGitRepositoryError(rootPath, err))
+	}
+	repositoryConfig, err := repository.Config()
+	if err != nil {
+		return "", "", errorhandler.NewUserError(message.GetGitRepositoryError(rootPath, err))
+	}
+	// Relative hooks paths are relative to the root of the working tree, like git does
+	if hooksPath := repositoryConfig.Raw.Section("core").Option("hooksPath"); hooksPath != "" {
+		if !filepath.IsAbs(hooksPath) {
+			hooksPath = filepath.Join(worktree.Filesystem.Root(), hooksPath)
+		}
+		return hooksPath, worktree.Filesystem.Root(), nil
+	}
+	storage, isFilesystemStorage := repository.Storer.(*filesystem.Storage)
+	if !isFilesystemStorage {
+		return filepath.Join(worktree.Filesystem.Root(), ".git", "hooks"), worktree.Filesystem.Root(), nil
+	}
+	return filepath.Join(storage.Filesystem().Root(), "hooks"), worktree.Filesystem.Root(), nil
+}
@@ -0,0 +1,59 @@
+// Package precommit scans the content staged in the git index before it is committed, and installs the git hook doing it
+package precommit
+
+import (
+	"context"
+	"strings"
+
+	"github.com/certinia/asist/config"
+	"github.com/certinia/asist/engine"
+	"github.com/certinia/asist/files"
+	"github.com/certinia/asist/files/gitdiff"
+	"github.com/certinia/asist/files/ignore"
+	"github.com/certinia/asist/finding"
+)
+
+/**
+ * ScanStagedFiles - method used to scan the staged content of the files added or modified in the git index of the repository
+ *	containing the root path. The files ignored by the ignore files or excluded by the config are not scanned.
+ */
+func ScanStagedFiles(ctx context.Context, rootPath string, cfg *config.Config, scanOptions engine.ScanOptions) (*finding.Output, error) {
+	stagedFiles, err := gitdiff.GetStagedFiles(rootPath)
+	if err != nil {
+		return nil, err
+	}
+	repositoryRoot := files.GetRepositoryRoot(rootPath)
+	matcher, err := ignore.GetIgnoreFilesPatterns(ignore.IgnoreOptions{
+		RootPath:        repositoryRoot,
+		DontGitIgnore:   cfg != nil && cfg.DontGitIgnore,
+		DontForceIgnore: cfg != nil && cfg.DontForceIgnore,
+	})
+	if err != nil {
+		return nil, err
+	}
+	stagedFilesByPath := map[string]gitdiff.StagedFile{}
+	filePaths := []string{}
+	for _, stagedFile := range stagedFiles {
+		if matcher != nil && matcher.Match(strings.Split(stagedFile.RelPath, "/"), false) {
+			continue
+		}
+		stagedFilesByPath[stagedFile.Path] = stagedFile
+		filePaths = append(filePaths, stagedFile.Path)
+	}
+	filePaths = cfg.FilterExcludedFilesAndFolders(filePaths)
+
+	// Files are read from the staged blobs instead of the working tree, only once the scanner selected rules to run on them
+	scanOptions.ReadFile = func(path string, parseOptions files.ParseOptions) (*files.File, error) {
+		reader, err := stagedFilesByPath[path].Open()
+		if err != nil {
+			return nil, err
+		}
+		defer reader.Close()
+		return files.ParseWithOptions(path, reader, parseOptions)
+	}
+	scanEngine, err := engine.NewScanner(cfg, scanOptions)
+	if err != nil {
+		return nil, err
+	}
+	return scanEngine.ScanFiles(ctx, filePaths)
+}
@@ -0,0 +1,157 @@
+package precommit
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/go-git/go-git/v5"
+	"github.com/go-git/go-git/v5/plumbing/object"
+
+	"github.com/certinia/asist/engine"
+	"github.com/certinia/asist/rules"
+)
+
+/**
+ * createRepository - helper used to create a git repository with an initial commit
+ */
+func createRepository(t *testing.T) (string, *git.Worktree) {
+	rootPath := t.TempDir()
+	repository, err := git.PlainInit(rootPath, false)
+	if err != nil {
+		t.Fatal(err)
+	}
+	worktree, _ := repository.Worktree()
+	writeFile(t, filepath.Join(rootPath, "README.md"), "readme\n")
+	worktree.Add("README.md")
+	_, err = worktree.Commit("initial", &git.CommitOptions{
+		Author: &object.Signature{Name: "test", Email: "test@example.com", When: time.Now()},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	return rootPath, worktree
+}
+
+func writeFile(t *testing.T, path string, content string) {
+	if err := os.WriteFile(path, []byte(content), 0600); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestScanStagedFiles_WhenStagedContentDiffersFromWorkingTree_ScansStagedContent(t *testing.T) {
+	//Given
+	rootPath, worktree := createRepository(t)
+	writeFile(t, filepath.Join(rootPath, "Staged.cls"), "public class Staged {\n}\n")
+	worktree.Add("Staged.cls")
+	writeFile(t, filepath.Join(rootPath, "Staged.cls"), "public with sharing class Staged {\n}\n")
+	writeFile(t, filepath.Join(rootPath, "Unstaged.cls"), "public class Unstaged {\n}\n")
+
+	//When
+	actualResult, err := ScanStagedFiles(context.Background(), rootPath, nil, engine.ScanOptions{Rules: []rules.RuleID{"ApexClassNoSharing"}, Jobs: 1})
+
+	//Then
+	if err != nil {
+		t.Fatalf("Should not return any error! %v", err)
+	}
+	if len(actualResult.Results) != 1 || actualResult.Results[0].Occurrence.FileName != filepath.Join(rootPath, "Staged.cls") {
+		t.Errorf("Expected a finding in the staged file only. Actual: %+v", actualResult.Results)
+	}
+}
+
+func TestInstallHook_WhenNoHookExists_WritesExecutableHook(t *testing.T) {
+	//Given
+	rootPath, _ := createRepository(t)
+
+	//When
+	hookPath, err := InstallHook(rootPath, "config.yaml", false)
+
+	//Then
+	if err != nil {
+		t.Fatalf("Should not return any error! %v", err)
+	}
+	if hookPath != filepath.Join(rootPath, ".git", "hooks", "pre-commit") {
+		t.Errorf("Hook path mismatched. Actual %s", hookPath)
+	}
+	content, _ := os.ReadFile(hookPath)
+	expected := "#!/bin/sh\n" + hookMarker + "\nexec asist -c 'config.yaml' pre-commit\n"
+	if string(content) != expected {
+		t.Errorf("Hook script mismatched.\n Actual %q\n Expected %q", content, expected)
+	}
+	if info, _ := os.Stat(hookPath); info.Mode()&0111 == 0 {
+		t.Errorf("Expected an executable hook. Actual mode %v", info.Mode())
+	}
+}
+
+func TestInstallHook_WhenConfigFileIsInSubfolder_WritesPathRelativeToRepositoryRoot(t *testing.T) {
+	//Given
+	rootPath, _ := createRepository(t)
+	subfolderPath := filepath.Join(rootPath, "force-app")
+	os.MkdirAll(subfolderPath, 0750)
+	outsideConfigFilePath := filepath.Join(t.TempDir(), "config.yaml")
+
+	//When
+	hookPath, err := InstallHook(subfolderPath, filepath.Join("..", "config", "config.yaml"), false)
+	content, _ := os.ReadFile(hookPath)
+	_, outsideErr := InstallHook(rootPath, outsideConfigFilePath, false)
+	outsideContent, _ := os.ReadFile(hookPath)
+
+	//Then
+	if err != nil || outsideErr != nil {
+		t.Fatalf("Should not return any error! %v, %v", err, outsideErr)
+	}
+	if !strings.Contains(string(content), "exec asist -c 'config/config.yaml' pre-commit") {
+		t.Errorf("Config file should be relative to the repository root. Actual %q", content)
+	}
+	if !strings.Contains(string(outsideContent), "exec asist -c '"+filepath.ToSlash(outsideConfigFilePath)+"' pre-commit") {
+		t.Errorf("Config file outside the repository should be absolute. Actual %q", outsideContent)
+	}
+}
+
+func TestInstallHook_WhenForeignHookExists_ReturnsErrorUnlessForced(t *testing.T) {
+	//Given
+	rootPath, _ := createRepository(t)
+	hookPath := filepath.Join(rootPath, ".git", "hooks", "pre-commit")
+	os.MkdirAll(filepath.Dir(hookPath), 0750)
+	writeFile(t, hookPath, "#!/bin/sh\nmake lint\n")
+
+	//When
+	_, err := InstallHook(rootPath, "", false)
+	_, forcedErr := InstallHook(rootPath, "", true)
+
+	//Then
+	if err == nil {
+		t.Errorf("Expected an error as the existing hook was not installed by ASIST")
+	}
+	if forcedErr != nil {
+		t.Errorf("Should not return any error when forced! %v", forcedErr)
+	}
+	if content, _ := os.ReadFile(hookPath); !strings.Contains(string(content), "exec asist pre-commit") {
+		t.Errorf("Expected the hook to be overwritten. Actual %q", content)
+	}
+}
+
+func TestInstallHook_WhenHooksPathIsConfigured_WritesIntoHooksPath(t *testing.T) {
+	//Given
+	rootPath, _ := createRepository(t)
+	repository, _ := git.PlainOpen(rootPath)
+	repositoryConfig, _ := repository.Config()
+	repositoryConfig.Raw.Section("core").SetOption("hooksPath", ".githooks")
+	if err := repository.SetConfig(repositoryConfig); err != nil {
+		t.Fatal(err)
+	}
+
+	//When
+	hookPath, err := InstallHook(rootPath, "", false)
+
+	//Then
+	if err != nil {
+		t.Fatalf("Should not return any error! %v", err)
+	}
+	if hookPath != filepath.Join(rootPath, ".githooks", "pre-commit") {
+		t.Errorf("Hook path mismatched. Actual %s", hookPath)
+	}
+}
@@ -1,26 +1,30 @@
 package regexrulehelper
 
 import (
+	"context"
 	"path/filepath"
 	"regexp"
 
 	"github.com/certinia/asist/files"
-	"github.com/certinia/asist/parser/options"
 	"github.com/certinia/asist/rules"
 )
 
 /**
 *	FindOccurancesForFile - Method will return occurrences found in the file
  */
-func FindOccurancesForFile(fileToScan files.File, ruleToScan *rules.RuleMetadata, isCommentedLinesIncluded bool) []rules.Occurrence {
+func FindOccurancesForFile(ctx context.Context, fileToScan files.File, ruleToScan *rules.RuleMetadata, isCommentedLinesIncluded bool) []rules.Occurrence {
 	var occurrences []rules.Occurrence
 	compiledPattern := regexp.MustCompile(ruleToScan.Pattern)
 	compiledQualifier := regexp.MustCompile(ruleToScan.Qualifier)
 	foundQualifier := false
 
 	for _, line := range fileToScan.Lines {
+		// Stop scanning once the scan of the file is canceled or timed out
+		if ctx.Err() != nil {
+			break
+		}
 		isFalsePositive := fileToScan.IsLineMarkedFalsePositive(string(ruleToScan.ID), line.LineNumber)
-		if (!isCommentedLinesIncluded && line.IsCommentedLine) || (isFalsePositive && !options.IsBaselineScan()) {
+		if (!isCommentedLinesIncluded && line.IsCommentedLine) || (isFalsePositive && !fileToScan.IncludeFalsePositives) {
 			continue
 		}
 
@@ -38,6 +42,7 @@ func FindOccurancesForFile(fileToScan files.File, ruleToScan *rules.RuleMetadata
 						LineNumber:      line.LineNumber,
 						LineContent:     line.Text,
 						ColumnRange:     value,
+						ColumnOffset:    line.ColumnOffset,
 						IsFalsePositive: isFalsePositive,
 					},
 				)
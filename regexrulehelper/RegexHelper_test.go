@@ -1,6 +1,7 @@
 package regexrulehelper
 
 import (
+	"context"
 	"reflect"
 	"regexp"
 	"testing"
@@ -135,7 +136,7 @@ func TestFindMatchesForFile_WhereFileLinesGenuine_ReturnsOutput(t *testing.T) {
 		},
 	}
 	//When
-	actualResult := FindOccurancesForFile(fileToScan, &metadata, true)
+	actualResult := FindOccurancesForFile(context.Background(), fileToScan, &metadata, true)
 
 	//Then
 	if !reflect.DeepEqual(actualResult, expectedResult) {
@@ -168,7 +169,7 @@ func TestFindMatchesForFile_WhenQualifierIsValidAndHavingZeroResultsFound_Return
 	expectedResult := []rules.Occurrence{}
 
 	//When
-	actualResult := FindOccurancesForFile(fileToScan, &metadata, true)
+	actualResult := FindOccurancesForFile(context.Background(), fileToScan, &metadata, true)
 
 	//Then
 	if len(actualResult) != len(expectedResult) {
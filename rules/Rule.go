@@ -1,6 +1,7 @@
 package rules
 
 import (
+	"context"
 	"fmt"
 
 	"github.com/certinia/asist/files"
@@ -56,6 +57,9 @@ type Occurrence struct {
 	LineNumber      int    `json:"LineNumber"`
 	ColumnRange     []int  `json:"ColumnRange"`
 	IsFalsePositive bool   `json:"-"`
+	// ColumnOffset is the position of LineContent in the source line, non zero for the chunks of a long line after the first one.
+	// ColumnRange is relative to LineContent until the scan adds the offset to it
+	ColumnOffset int `json:"-"`
 }
 
 type RuleMetadataOverride struct {
@@ -84,9 +88,11 @@ func (md *RuleMetadata) Override(overrideRule RuleMetadataOverride, isBaselineSc
 	}
 }
 
-// Rule is the generic rule interface. All functions in it must be declared for a rule type
+// Rule is the generic rule interface. All functions in it must be declared for a rule type.
+// A single rule instance is shared by all scan workers, so Run must not modify the rule or any package level state.
+// Run should stop early once ctx is done, e.g. when the scan of the file timed out, its result is then discarded
 type Rule interface {
-	Run(fileToScan files.File) []Occurrence
+	Run(ctx context.Context, fileToScan files.File) []Occurrence
 	GetMetadata() *RuleMetadata
 }
 
@@ -1,6 +1,8 @@
 package customrule
 
 import (
+	"context"
+
 	"github.com/certinia/asist/config"
 	"github.com/certinia/asist/files"
 	"github.com/certinia/asist/regexrulehelper"
@@ -43,6 +45,6 @@ func (r *CustomRule) GetMetadata() *rules.RuleMetadata {
 /**
  * Run - method used to run a custom rule
  */
-func (r *CustomRule) Run(fileToScan files.File) []rules.Occurrence {
-	return regexrulehelper.FindOccurancesForFile(fileToScan, &r.metadata, false)
+func (r *CustomRule) Run(ctx context.Context, fileToScan files.File) []rules.Occurrence {
+	return regexrulehelper.FindOccurancesForFile(ctx, fileToScan, &r.metadata, false)
 }
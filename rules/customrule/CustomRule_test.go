@@ -1,6 +1,7 @@
 package customrule
 
 import (
+	"context"
 	"reflect"
 	"testing"
 
@@ -104,7 +105,7 @@ func TestRun(t *testing.T) {
 	customRuleInstance := NewCustomRule(customRule, "customrule1")
 
 	// When
-	actualOccurrenceResult := customRuleInstance.Run(mockFile)
+	actualOccurrenceResult := customRuleInstance.Run(context.Background(), mockFile)
 
 	// Then
 	if !reflect.DeepEqual(actualOccurrenceResult[0], expectedOccurrenceResult) {
@@ -1,12 +1,12 @@
 package codequality
 
 import (
+	"context"
 	"regexp"
 	"slices"
 	"strings"
 
 	"github.com/certinia/asist/files"
-	"github.com/certinia/asist/parser/options"
 	"github.com/certinia/asist/utils"
 )
 
@@ -66,8 +66,11 @@ type functionsDetail struct {
 /**
  * getFunctionsList - method used to get the list of all the functions in a file
  */
-func getFunctionsList(currentRuleId string, fileToScan files.File) []functionsDetail {
-	fileContentAsString, lengthOfEachLine := convertFileIntoSingleString(currentRuleId, fileToScan)
+func getFunctionsList(ctx context.Context, currentRuleId string, fileToScan files.File) []functionsDetail {
+	fileContentAsString, lengthOfEachLine := convertFileIntoSingleString(ctx, currentRuleId, fileToScan)
+	if ctx.Err() != nil {
+		return nil
+	}
 	constructor := getConstructorRegex(fileContentAsString)
 	functionOrConstructor := ""
 
@@ -160,7 +163,7 @@ func getConstructorRegex(fileContentAsString string) string {
 /**
  * convertFileIntoSingleString - method used to convert a file content into a single string
  */
-func convertFileIntoSingleString(currentRuleId string, fileToScan files.File) (string, []int) {
+func convertFileIntoSingleString(ctx context.Context, currentRuleId string, fileToScan files.File) (string, []int) {
 	fileContentAsString := ""
 	sumOfCurrentAndPreviousLineLength := 0
 	/**
@@ -170,8 +173,12 @@ func convertFileIntoSingleString(currentRuleId string, fileToScan files.File) (s
 	lengthOfEachLine := []int{}
 
 	for _, line := range fileToScan.Lines {
+		// Stop scanning once the scan of the file is canceled or timed out
+		if ctx.Err() != nil {
+			break
+		}
 		isFalsePositive := fileToScan.IsLineMarkedFalsePositive(currentRuleId, line.LineNumber)
-		if !line.IsCommentedLine && (!isFalsePositive || options.IsBaselineScan()) {
+		if !line.IsCommentedLine && (!isFalsePositive || fileToScan.IncludeFalsePositives) {
 			commentIndexs := commentsRegexp.FindStringIndex(line.Text)
 			lineLength := len(line.Text)
 			if len(commentIndexs) != 0 {
@@ -1,6 +1,7 @@
 package codequality
 
 import (
+	"context"
 	"reflect"
 	"regexp"
 	"testing"
@@ -35,7 +36,7 @@ func TestGetFunctionsList_WhenFileProvided_ReturnsAllFunctionsInFile(t *testing.
 	}
 
 	// When
-	actualFunctionsList := getFunctionsList("DetectMissingAccessibilityModifier", mockFile)
+	actualFunctionsList := getFunctionsList(context.Background(), "DetectMissingAccessibilityModifier", mockFile)
 
 	// Then
 	if !reflect.DeepEqual(actualFunctionsList, expectedFunctionsList) {
@@ -57,7 +58,7 @@ func TestGetFunctionsList_WhenNoConstructorRegex_ReturnsEmptyFunctionsList(t *te
 	}
 
 	// When
-	actualFunctionsList := getFunctionsList("DetectMissingAccessibilityModifier", mockFile)
+	actualFunctionsList := getFunctionsList(context.Background(), "DetectMissingAccessibilityModifier", mockFile)
 
 	// Then
 	if actualFunctionsList != nil {
@@ -132,7 +133,7 @@ func TestConvertFileIntoSingleString_WhenFileExist_ReturnsSingleString(t *testin
 	expectedLinesLength := []int{22, 41, 41, 86, 93, 94, 95}
 
 	// When
-	actualStringResult, actualLineslength := convertFileIntoSingleString("DetectMissingAccessibilityModifier", mockFile)
+	actualStringResult, actualLineslength := convertFileIntoSingleString(context.Background(), "DetectMissingAccessibilityModifier", mockFile)
 
 	// Then
 	if !reflect.DeepEqual(actualStringResult, expectedStringResult) {
@@ -1,6 +1,8 @@
 package codequality
 
 import (
+	"context"
+
 	"github.com/certinia/asist/files"
 	"github.com/certinia/asist/regexrulehelper"
 	"github.com/certinia/asist/rules"
@@ -31,6 +33,6 @@ func (r *DetectImportJavascriptFromFileRule) GetMetadata() *rules.RuleMetadata {
 	return &r.metadata
 }
 
-func (r *DetectImportJavascriptFromFileRule) Run(fileToScan files.File) []rules.Occurrence {
-	return regexrulehelper.FindOccurancesForFile(fileToScan, &r.metadata, false)
+func (r *DetectImportJavascriptFromFileRule) Run(ctx context.Context, fileToScan files.File) []rules.Occurrence {
+	return regexrulehelper.FindOccurancesForFile(ctx, fileToScan, &r.metadata, false)
 }
@@ -1,6 +1,7 @@
 package codequality
 
 import (
+	"context"
 	"regexp"
 
 	"github.com/certinia/asist/files"
@@ -32,15 +33,15 @@ func (r *DetectMissingAccessibilityModifierRule) GetMetadata() *rules.RuleMetada
 	return &r.metadata
 }
 
-func (r *DetectMissingAccessibilityModifierRule) Run(file files.File) []rules.Occurrence {
-	return findMatchesForDetectMissingAccessibilityModifier(file, &r.metadata)
+func (r *DetectMissingAccessibilityModifierRule) Run(ctx context.Context, file files.File) []rules.Occurrence {
+	return findMatchesForDetectMissingAccessibilityModifier(ctx, file, &r.metadata)
 }
 
-func findMatchesForDetectMissingAccessibilityModifier(fileToScan files.File, ruleMetadata *rules.RuleMetadata) []rules.Occurrence {
+func findMatchesForDetectMissingAccessibilityModifier(ctx context.Context, fileToScan files.File, ruleMetadata *rules.RuleMetadata) []rules.Occurrence {
 	var output []rules.Occurrence
 	accessibilityModifiersRegexp := regexp.MustCompile(ruleMetadata.Pattern)
 
-	for _, functionInfo := range getFunctionsList(string(ruleMetadata.ID), fileToScan) {
+	for _, functionInfo := range getFunctionsList(ctx, string(ruleMetadata.ID), fileToScan) {
 		if !(accessibilityModifiersRegexp.MatchString(fileToScan.Lines[functionInfo.lineIndex].Text)) {
 			lineNumber := fileToScan.Lines[functionInfo.lineIndex].LineNumber
 			output = append(
@@ -50,6 +51,7 @@ func findMatchesForDetectMissingAccessibilityModifier(fileToScan files.File, rul
 					LineNumber:      lineNumber,
 					LineContent:     fileToScan.Lines[functionInfo.lineIndex].Text,
 					ColumnRange:     []int{0, len(fileToScan.Lines[functionInfo.lineIndex].Text) - 1},
+					ColumnOffset:    fileToScan.Lines[functionInfo.lineIndex].ColumnOffset,
 					IsFalsePositive: fileToScan.IsLineMarkedFalsePositive(string(ruleMetadata.ID), lineNumber),
 				},
 			)
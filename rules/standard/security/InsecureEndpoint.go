@@ -1,10 +1,10 @@
 package security
 
 import (
+	"context"
 	"regexp"
 
 	"github.com/certinia/asist/files"
-	"github.com/certinia/asist/parser/options"
 	"github.com/certinia/asist/rules"
 )
 
@@ -33,19 +33,23 @@ func (r *InsecureEndpoint) GetMetadata() *rules.RuleMetadata {
 	return &r.metadata
 }
 
-func (r *InsecureEndpoint) Run(fileToScan files.File) []rules.Occurrence {
-	return findMatchesForInsecureEndpoint(fileToScan, &r.metadata)
+func (r *InsecureEndpoint) Run(ctx context.Context, fileToScan files.File) []rules.Occurrence {
+	return findMatchesForInsecureEndpoint(ctx, fileToScan, &r.metadata)
 
 }
 
-func findMatchesForInsecureEndpoint(fileToScan files.File, ruleMetadata *rules.RuleMetadata) []rules.Occurrence {
+func findMatchesForInsecureEndpoint(ctx context.Context, fileToScan files.File, ruleMetadata *rules.RuleMetadata) []rules.Occurrence {
 	var output []rules.Occurrence
 	httpRegexp := regexp.MustCompile(ruleMetadata.Pattern)
 	xmlnsRegexp := regexp.MustCompile(`\s+xmlns\s*(=|:)`)
 
 	for _, line := range fileToScan.Lines {
+		// Stop scanning once the scan of the file is canceled or timed out
+		if ctx.Err() != nil {
+			break
+		}
 		isFalsePositive := fileToScan.IsLineMarkedFalsePositive(string(ruleMetadata.ID), line.LineNumber)
-		if line.IsCommentedLine || (isFalsePositive && !options.IsBaselineScan()) {
+		if line.IsCommentedLine || (isFalsePositive && !fileToScan.IncludeFalsePositives) {
 			continue
 		}
 		isHttpOccurrenceInLine := httpRegexp.MatchString(line.Text)
@@ -59,6 +63,7 @@ func findMatchesForInsecureEndpoint(fileToScan files.File, ruleMetadata *rules.R
 						LineNumber:      line.LineNumber,
 						LineContent:     line.Text,
 						ColumnRange:     []int{0, len(line.Text) - 1},
+						ColumnOffset:    line.ColumnOffset,
 						IsFalsePositive: isFalsePositive,
 					},
 				)
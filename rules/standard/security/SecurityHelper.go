@@ -1,10 +1,10 @@
 package security
 
 import (
+	"context"
 	"regexp"
 
 	"github.com/certinia/asist/files"
-	"github.com/certinia/asist/parser/options"
 	"github.com/certinia/asist/rules"
 	"github.com/certinia/asist/utils"
 )
@@ -16,7 +16,7 @@ var selfCloseScriptStyleTagRegexp = regexp.MustCompile(`<\s*(script|style).*/\s*
 /**
  * findVulnerableLinesBetweenTags - method used to find all the vulnerable lines between (script/style) tag or any vulnerable tag in a file based on rule Id
  */
-func findVulnerableLinesBetweenTags(fileToScan files.File, ruleId string, extraVulnerableTags []string, isCommentedLinesIncluded bool) []rules.Occurrence {
+func findVulnerableLinesBetweenTags(ctx context.Context, fileToScan files.File, ruleId string, extraVulnerableTags []string, isCommentedLinesIncluded bool) []rules.Occurrence {
 	var isInsideScriptOrStyleTag = false
 	var hasOpeningScriptOrStyleTagFound = false
 	var vulnerableLines = []rules.Occurrence{}
@@ -29,10 +29,14 @@ func findVulnerableLinesBetweenTags(fileToScan files.File, ruleId string, extraV
 	}
 
 	for _, line := range fileToScan.Lines {
+		// Stop scanning once the scan of the file is canceled or timed out
+		if ctx.Err() != nil {
+			break
+		}
 		columnRange = [2]int{}
 		isInsideScriptOrStyleTag = false
 		isFalsePositive := fileToScan.IsLineMarkedFalsePositive(ruleId, line.LineNumber)
-		if (!isCommentedLinesIncluded && line.IsCommentedLine) || (isFalsePositive && !options.IsBaselineScan()) {
+		if (!isCommentedLinesIncluded && line.IsCommentedLine) || (isFalsePositive && !fileToScan.IncludeFalsePositives) {
 			continue
 		}
 		if hasOpeningScriptOrStyleTagFound {
@@ -73,12 +77,14 @@ func findVulnerableLinesBetweenTags(fileToScan files.File, ruleId string, extraV
 					LineNumber:      line.LineNumber,
 					LineContent:     line.Text,
 					ColumnRange:     []int{columnRange[0], columnRange[1]},
+					ColumnOffset:    line.ColumnOffset,
 					IsFalsePositive: isFalsePositive,
 				})
 			} else {
 				vulnerableLines = append(vulnerableLines, rules.Occurrence{
 					LineNumber:      line.LineNumber,
 					LineContent:     line.Text,
+					ColumnOffset:    line.ColumnOffset,
 					IsFalsePositive: isFalsePositive,
 				})
 			}
@@ -88,6 +94,7 @@ func findVulnerableLinesBetweenTags(fileToScan files.File, ruleId string, extraV
 					LineNumber:      line.LineNumber,
 					LineContent:     line.Text,
 					ColumnRange:     extraVulnerableTag,
+					ColumnOffset:    line.ColumnOffset,
 					IsFalsePositive: isFalsePositive,
 				})
 			}
@@ -1,10 +1,10 @@
 package security
 
 import (
+	"context"
 	"regexp"
 
 	"github.com/certinia/asist/files"
-	"github.com/certinia/asist/parser/options"
 	"github.com/certinia/asist/rules"
 )
 
@@ -33,12 +33,12 @@ func (r *SensitiveInfoInDebugRule) GetMetadata() *rules.RuleMetadata {
 	return &r.metadata
 }
 
-func (r *SensitiveInfoInDebugRule) Run(fileToScan files.File) []rules.Occurrence {
-	result := findSensitiveInfoInDebug(fileToScan, string(SensitiveInfoInDebugRuleID), r.metadata.Pattern)
+func (r *SensitiveInfoInDebugRule) Run(ctx context.Context, fileToScan files.File) []rules.Occurrence {
+	result := findSensitiveInfoInDebug(ctx, fileToScan, string(SensitiveInfoInDebugRuleID), r.metadata.Pattern)
 	return result
 }
 
-func findSensitiveInfoInDebug(fileToScan files.File, currentRuleID string, pattern string) []rules.Occurrence {
+func findSensitiveInfoInDebug(ctx context.Context, fileToScan files.File, currentRuleID string, pattern string) []rules.Occurrence {
 	var output []rules.Occurrence
 	debugMethodRegexp := regexp.MustCompile(pattern)
 	findSystemDebugClosingBracket := regexp.MustCompile(`\);`)
@@ -48,10 +48,15 @@ func findSensitiveInfoInDebug(fileToScan files.File, currentRuleID string, patte
 	systemDebugActualLine := ""
 	systemDebugColumnRange := []int{-1, -1}
 	systemDebugLineNumber := -1
+	systemDebugColumnOffset := 0
 	systemDebugVirtualLine := ""
 	for _, line := range fileToScan.Lines {
+		// Stop scanning once the scan of the file is canceled or timed out
+		if ctx.Err() != nil {
+			break
+		}
 		isFalsePositive := fileToScan.IsLineMarkedFalsePositive(currentRuleID, line.LineNumber)
-		if (!isCommentedLinesIncluded && line.IsCommentedLine) || (isFalsePositive && !options.IsBaselineScan()) {
+		if (!isCommentedLinesIncluded && line.IsCommentedLine) || (isFalsePositive && !fileToScan.IncludeFalsePositives) {
 			continue
 		}
 		isDebugLine := debugMethodRegexp.MatchString(line.Text)
@@ -63,6 +68,7 @@ func findSensitiveInfoInDebug(fileToScan files.File, currentRuleID string, patte
 			if isDebugLine {
 				systemDebugLineNumber = line.LineNumber
 				systemDebugActualLine = line.Text
+				systemDebugColumnOffset = line.ColumnOffset
 				systemDebugColumnRange = debugMethodRegexp.FindStringIndex(line.Text)
 			}
 
@@ -78,6 +84,7 @@ func findSensitiveInfoInDebug(fileToScan files.File, currentRuleID string, patte
 							LineNumber:      systemDebugLineNumber,
 							LineContent:     systemDebugActualLine,
 							ColumnRange:     systemDebugColumnRange,
+							ColumnOffset:    systemDebugColumnOffset,
 							IsFalsePositive: isFalsePositive,
 						},
 					)
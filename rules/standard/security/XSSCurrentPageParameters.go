@@ -1,6 +1,7 @@
 package security
 
 import (
+	"context"
 	"regexp"
 
 	"github.com/certinia/asist/files"
@@ -33,11 +34,11 @@ func (r *XSSCurrentPageParametersRule) GetMetadata() *rules.RuleMetadata {
 	return &r.metadata
 }
 
-func (r *XSSCurrentPageParametersRule) Run(fileToScan files.File) []rules.Occurrence {
-	return findMatchesForCurrentPageParameters(fileToScan, &r.metadata)
+func (r *XSSCurrentPageParametersRule) Run(ctx context.Context, fileToScan files.File) []rules.Occurrence {
+	return findMatchesForCurrentPageParameters(ctx, fileToScan, &r.metadata)
 }
 
-func findMatchesForCurrentPageParameters(fileToScan files.File, ruleMetadata *rules.RuleMetadata) []rules.Occurrence {
+func findMatchesForCurrentPageParameters(ctx context.Context, fileToScan files.File, ruleMetadata *rules.RuleMetadata) []rules.Occurrence {
 	var output []rules.Occurrence
 
 	currentPageParametersRegexp := regexp.MustCompile(ruleMetadata.Pattern)
@@ -49,7 +50,7 @@ func findMatchesForCurrentPageParameters(fileToScan files.File, ruleMetadata *ru
 
 	extraVulnerableTagsRegexp := []string{vulnerableLinkTagsRegex, vulnerableOnEventRegex}
 
-	for _, line := range findVulnerableLinesBetweenTags(fileToScan, string(ruleMetadata.ID), extraVulnerableTagsRegexp, false) {
+	for _, line := range findVulnerableLinesBetweenTags(ctx, fileToScan, string(ruleMetadata.ID), extraVulnerableTagsRegexp, false) {
 		subLineRange1 := 0
 		subLineRange2 := 0
 		tempLineText := line.LineContent
@@ -70,6 +71,7 @@ func findMatchesForCurrentPageParameters(fileToScan files.File, ruleMetadata *ru
 						LineContent:     line.LineContent,
 						LineNumber:      line.LineNumber,
 						ColumnRange:     []int{currentPageStartIndex, currentPageEndIndex},
+						ColumnOffset:    line.ColumnOffset,
 						IsFalsePositive: line.IsFalsePositive,
 					},
 				)
@@ -1,6 +1,7 @@
 package security
 
 import (
+	"context"
 	"regexp"
 
 	"github.com/certinia/asist/files"
@@ -33,11 +34,11 @@ func (r *XSSLabelRule) GetMetadata() *rules.RuleMetadata {
 	return &r.metadata
 }
 
-func (r *XSSLabelRule) Run(fileToScan files.File) []rules.Occurrence {
-	return findMatchesForXssLabel(fileToScan, &r.metadata)
+func (r *XSSLabelRule) Run(ctx context.Context, fileToScan files.File) []rules.Occurrence {
+	return findMatchesForXssLabel(ctx, fileToScan, &r.metadata)
 }
 
-func findMatchesForXssLabel(fileToScan files.File, ruleMetadata *rules.RuleMetadata) []rules.Occurrence {
+func findMatchesForXssLabel(ctx context.Context, fileToScan files.File, ruleMetadata *rules.RuleMetadata) []rules.Occurrence {
 	var output []rules.Occurrence
 
 	labelRegexp := regexp.MustCompile(ruleMetadata.Pattern)
@@ -49,7 +50,7 @@ func findMatchesForXssLabel(fileToScan files.File, ruleMetadata *rules.RuleMetad
 
 	extraVulnerableTagsRegexp := []string{vulnerableLinkTagsRegex, vulnerableOnEventRegex}
 
-	for _, line := range findVulnerableLinesBetweenTags(fileToScan, string(ruleMetadata.ID), extraVulnerableTagsRegexp, false) {
+	for _, line := range findVulnerableLinesBetweenTags(ctx, fileToScan, string(ruleMetadata.ID), extraVulnerableTagsRegexp, false) {
 		subLineRange1 := 0
 		subLineRange2 := 0
 		tempLineText := line.LineContent
@@ -71,6 +72,7 @@ func findMatchesForXssLabel(fileToScan files.File, ruleMetadata *rules.RuleMetad
 						LineContent:     line.LineContent,
 						LineNumber:      line.LineNumber,
 						ColumnRange:     []int{labelStartIndex, labelEndIndex},
+						ColumnOffset:    line.ColumnOffset,
 						IsFalsePositive: line.IsFalsePositive,
 					},
 				)
@@ -1,6 +1,7 @@
 package security
 
 import (
+	"context"
 	"regexp"
 
 	"github.com/certinia/asist/files"
@@ -32,11 +33,11 @@ func (r *XSSMergeFieldRule) GetMetadata() *rules.RuleMetadata {
 	return &r.metadata
 }
 
-func (r *XSSMergeFieldRule) Run(fileToScan files.File) []rules.Occurrence {
-	return findXssMergeField(fileToScan, &r.metadata)
+func (r *XSSMergeFieldRule) Run(ctx context.Context, fileToScan files.File) []rules.Occurrence {
+	return findXssMergeField(ctx, fileToScan, &r.metadata)
 }
 
-func findXssMergeField(fileToScan files.File, ruleMetadata *rules.RuleMetadata) []rules.Occurrence {
+func findXssMergeField(ctx context.Context, fileToScan files.File, ruleMetadata *rules.RuleMetadata) []rules.Occurrence {
 	var output []rules.Occurrence
 
 	salesforceGlobalVariables := "\\$(Label|RemoteAction|Profile|Page|CurrentPage|Permission|Resource|Component|Action|User\\.UITheme|ObjectType[^\\}]*\\.(Name|Createable|Updateable|Deletable|KeyPrefix)[^\\.])"
@@ -50,7 +51,7 @@ func findXssMergeField(fileToScan files.File, ruleMetadata *rules.RuleMetadata)
 	patternToExcludeRegexp := regexp.MustCompile("((?i)" + salesforceGlobalVariables + "|" + booleanPattern + "|" + numberPattern + ")" + "|" + encodedFunction + "|" + booleanCamelCasePattern + "|" + idPattern)
 	mergefieldRegexp := regexp.MustCompile(ruleMetadata.Pattern)
 
-	for _, line := range findVulnerableLinesBetweenTags(fileToScan, string(ruleMetadata.ID), []string{onEventRegexp}, false) {
+	for _, line := range findVulnerableLinesBetweenTags(ctx, fileToScan, string(ruleMetadata.ID), []string{onEventRegexp}, false) {
 		subLineRange1 := 0
 		subLineRange2 := 0
 		tempLineText := line.LineContent
@@ -75,6 +76,7 @@ func findXssMergeField(fileToScan files.File, ruleMetadata *rules.RuleMetadata)
 						LineContent:     line.LineContent,
 						LineNumber:      line.LineNumber,
 						ColumnRange:     []int{mergefield_range[0] + subLineRange1, mergefield_range[1] + subLineRange1},
+						ColumnOffset:    line.ColumnOffset,
 						IsFalsePositive: line.IsFalsePositive,
 					},
 				)
@@ -8,37 +8,101 @@ import (
 	security "github.com/certinia/asist/rules/standard/security"
 )
 
-var ruleMapping = map[rules.RuleID]rules.Rule{
-	codequality.DetectImportJavascriptFromFileRuleID:     codequality.NewDetectImportJavascriptFromFileRule(),
-	codequality.DetectMissingAccessibilityModifierRuleID: codequality.NewDetectMissingAccessibilityModifierRule(),
-	security.ApexClassNoSharingRuleID:                    security.NewApexClassNoSharingRule(),
-	security.ApexClassWithoutSharingRuleID:               security.NewApexClassWithoutSharingRule(),
-	security.AuraComponentCssExposedRuleID:               security.NewAuraComponentCssExposedRule(),
-	security.EmailInjectionRuleID:                        security.NewEmailInjectionRule(),
-	security.ExposedMessageChannelRuleID:                 security.NewExposedMessageChannelRule(),
-	security.HardcodedCredentialsRuleID:                  security.NewHardcodedCredentialsRule(),
-	security.InsecureCryptoAlgorithmRuleID:               security.NewInsecureCryptoAlgorithmRule(),
-	security.InsecureEndpointRuleID:                      security.NewInsecureEndpointRule(),
-	security.JSNotInStaticResourceRuleID:                 security.NewJSNotInStaticResourceRule(),
-	security.LightningImproperCSSLoadRuleID:              security.NewLightningImproperCSSLoadRule(),
-	security.LwcNonStandardPositioningRuleID:             security.NewLwcNonStandardPositioningRule(),
-	security.ProtectedCustomSettingRuleID:                security.NewProtectedCustomSettingRule(),
-	security.SensitiveInfoInDebugRuleID:                  security.NewSensitiveInfoInDebugRule(),
-	security.SessionIDApexRuleID:                         security.NewSessionIDApexRule(),
-	security.SessionIDVisualForceRuleID:                  security.NewSessionIDVisualForceRule(),
-	security.XSSApexChartRuleID:                          security.NewXSSApexChartRule(),
-	security.XSSAuraUnescapedHtmlRuleID:                  security.NewXSSAuraUnescapedHtmlRule(),
-	security.XSSCurrentPageParametersRuleID:              security.NewXSSCurrentPageParametersRule(),
-	security.XSSDomHtmlRuleID:                            security.NewXSSDomHtmlRule(),
-	security.XSSEscapeFalseRuleID:                        security.NewXSSEscapeFalseRule(),
-	security.XSSEscapeFalseInJSRuleID:                    security.NewXSSEscapeFalseInJSRule(),
-	security.XSSFormActionRuleID:                         security.NewXSSFormActionRule(),
-	security.XSSIsRichTextRuleID:                         security.NewXSSIsRichTextRule(),
-	security.XSSJavascriptButtonRuleID:                   security.NewXSSJavascriptButtonRule(),
-	security.XSSLabelRuleID:                              security.NewXSSLabelRule(),
-	security.XSSLocationSearchRuleID:                     security.NewXSSLocationSearchRule(),
-	security.XSSLwcDomManualRuleID:                       security.NewXSSLwcDomManualRule(),
-	security.XSSMergeFieldRuleID:                         security.NewXSSMergeFieldRule(),
-	security.XSSSrcDocRuleID:                             security.NewXSSSrcDocRule(),
-	security.XSSTooltipRuleID:                            security.NewXSSTooltipRule(),
+var ruleMapping = map[rules.RuleID]func() rules.Rule{
+	codequality.DetectImportJavascriptFromFileRuleID: func() rules.Rule {
+		return codequality.NewDetectImportJavascriptFromFileRule()
+	},
+	codequality.DetectMissingAccessibilityModifierRuleID: func() rules.Rule {
+		return codequality.NewDetectMissingAccessibilityModifierRule()
+	},
+	security.ApexClassNoSharingRuleID: func() rules.Rule {
+		return security.NewApexClassNoSharingRule()
+	},
+	security.ApexClassWithoutSharingRuleID: func() rules.Rule {
+		return security.NewApexClassWithoutSharingRule()
+	},
+	security.AuraComponentCssExposedRuleID: func() rules.Rule {
+		return security.NewAuraComponentCssExposedRule()
+	},
+	security.EmailInjectionRuleID: func() rules.Rule {
+		return security.NewEmailInjectionRule()
+	},
+	security.ExposedMessageChannelRuleID: func() rules.Rule {
+		return security.NewExposedMessageChannelRule()
+	},
+	security.HardcodedCredentialsRuleID: func() rules.Rule {
+		return security.NewHardcodedCredentialsRule()
+	},
+	security.InsecureCryptoAlgorithmRuleID: func() rules.Rule {
+		return security.NewInsecureCryptoAlgorithmRule()
+	},
+	security.InsecureEndpointRuleID: func() rules.Rule {
+		return security.NewInsecureEndpointRule()
+	},
+	security.JSNotInStaticResourceRuleID: func() rules.Rule {
+		return security.NewJSNotInStaticResourceRule()
+	},
+	security.LightningImproperCSSLoadRuleID: func() rules.Rule {
+		return security.NewLightningImproperCSSLoadRule()
+	},
+	security.LwcNonStandardPositioningRuleID: func() rules.Rule {
+		return security.NewLwcNonStandardPositioningRule()
+	},
+	security.ProtectedCustomSettingRuleID: func() rules.Rule {
+		return security.NewProtectedCustomSettingRule()
+	},
+	security.SensitiveInfoInDebugRuleID: func() rules.Rule {
+		return security.NewSensitiveInfoInDebugRule()
+	},
+	security.SessionIDApexRuleID: func() rules.Rule {
+		return security.NewSessionIDApexRule()
+	},
+	security.SessionIDVisualForceRuleID: func() rules.Rule {
+		return security.NewSessionIDVisualForceRule()
+	},
+	security.XSSApexChartRuleID: func() rules.Rule {
+		return security.NewXSSApexChartRule()
+	},
+	security.XSSAuraUnescapedHtmlRuleID: func() rules.Rule {
+		return security.NewXSSAuraUnescapedHtmlRule()
+	},
+	security.XSSCurrentPageParametersRuleID: func() rules.Rule {
+		return security.NewXSSCurrentPageParametersRule()
+	},
+	security.XSSDomHtmlRuleID: func() rules.Rule {
+		return security.NewXSSDomHtmlRule()
+	},
+	security.XSSEscapeFalseRuleID: func() rules.Rule {
+		return security.NewXSSEscapeFalseRule()
+	},
+	security.XSSEscapeFalseInJSRuleID: func() rules.Rule {
+		return security.NewXSSEscapeFalseInJSRule()
+	},
+	security.XSSFormActionRuleID: func() rules.Rule {
+		return security.NewXSSFormActionRule()
+	},
+	security.XSSIsRichTextRuleID: func() rules.Rule {
+		return security.NewXSSIsRichTextRule()
+	},
+	security.XSSJavascriptButtonRuleID: func() rules.Rule {
+		return security.NewXSSJavascriptButtonRule()
+	},
+	security.XSSLabelRuleID: func() rules.Rule {
+		return security.NewXSSLabelRule()
+	},
+	security.XSSLocationSearchRuleID: func() rules.Rule {
+		return security.NewXSSLocationSearchRule()
+	},
+	security.XSSLwcDomManualRuleID: func() rules.Rule {
+		return security.NewXSSLwcDomManualRule()
+	},
+	security.XSSMergeFieldRuleID: func() rules.Rule {
+		return security.NewXSSMergeFieldRule()
+	},
+	security.XSSSrcDocRuleID: func() rules.Rule {
+		return security.NewXSSSrcDocRule()
+	},
+	security.XSSTooltipRuleID: func() rules.Rule {
+		return security.NewXSSTooltipRule()
+	},
 }
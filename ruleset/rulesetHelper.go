@@ -3,29 +3,37 @@ package ruleset
 import (
 	"log"
 	"slices"
+	"sync"
 
 	"github.com/certinia/asist/config"
 	"github.com/certinia/asist/message"
-	"github.com/certinia/asist/parser/options"
 	"github.com/certinia/asist/rules"
 	"github.com/certinia/asist/rules/customrule"
 )
 
 var ruleIDs []rules.RuleID
+var ruleIDsOnce sync.Once
 
+/**
+ * GetAllStdRuleIDs - method used to get the sorted IDs of all standard rules, it can be called by several goroutines at once
+ */
 func GetAllStdRuleIDs() []rules.RuleID {
-	if len(ruleIDs) == 0 {
+	ruleIDsOnce.Do(func() {
 		for ruleID := range ruleMapping {
 			ruleIDs = append(ruleIDs, ruleID)
 		}
-	}
-	return ruleIDs
+		// Sort rule IDs so rules, and so findings of a file, are always in the same order
+		slices.Sort(ruleIDs)
+	})
+	// Callers get their own copy, so they cannot modify the shared list
+	return slices.Clone(ruleIDs)
 }
 
 /**
-* CreateRules - Method will create rules for provided ruleIds
+* CreateRules - Method will create new rule instances for provided ruleIds.
+*	Severity overrides are ignored for baseline scans.
  */
-func CreateAndOverrideRules(standardRuleIDs, customRuleIds []rules.RuleID, configFile *config.Config) ([]*rules.Rule, error) {
+func CreateAndOverrideRules(standardRuleIDs, customRuleIds []rules.RuleID, configFile *config.Config, isBaselineScan bool) ([]*rules.Rule, error) {
 	rules := []*rules.Rule{}
 	if configFile == nil {
 		configFile = &config.Config{}
@@ -43,7 +51,7 @@ func CreateAndOverrideRules(standardRuleIDs, customRuleIds []rules.RuleID, confi
 		}
 		if rule != nil {
 			if isStandardRuleOverride {
-				rule.GetMetadata().Override(standardRuleMetadataOverride, options.IsBaselineScan())
+				rule.GetMetadata().Override(standardRuleMetadataOverride, isBaselineScan)
 			}
 			rules = append(rules, &rule)
 		}
@@ -57,12 +65,12 @@ func IsStandardRuleID(ruleId rules.RuleID) *bool {
 }
 
 func createStandardRule(ruleId rules.RuleID) (rules.Rule, error) {
-	rule := ruleMapping[ruleId]
-	if rule == nil {
+	newRule := ruleMapping[ruleId]
+	if newRule == nil {
 		log.Println(message.GetInvalidRuleIdWarning(string(ruleId)))
 		return nil, nil
 	}
-	return rule, nil
+	return newRule(), nil
 }
 
 func createCustomRule(ruleMetadata config.CustomRegexRule, ruleID rules.RuleID) rules.Rule {
@@ -70,6 +78,16 @@ func createCustomRule(ruleMetadata config.CustomRegexRule, ruleID rules.RuleID)
 	return rule
 }
 
+// RuleSelection selects the rules to run in addition to the config, the zero value runs the rules enabled in the config
+type RuleSelection struct {
+	// Rules are the IDs of the rules to run instead of the rules enabled in the config
+	Rules []rules.RuleID
+	// CICDScan only runs the CI/CD rules of the config
+	CICDScan bool
+	// BaselineScan runs all standard and custom rules
+	BaselineScan bool
+}
+
 /**
  * GetRuleIdsToRun - Returns a map of rule IDs (standard, custom, CI/CD, specific) mapped to a boolean.
  * - If specificRuleIds are provided, returns map[specificRuleIds] = true.
@@ -77,18 +95,18 @@ func createCustomRule(ruleMetadata config.CustomRegexRule, ruleID rules.RuleID)
  * - If a config is provided, overrides standardRuleIds with the provided values and includes custom rule IDs.
  * - Otherwise, returns map[standardRuleIds] = true.
  */
-func GetRuleIdsToRun(configFile *config.Config, opts *options.Options) ([]rules.RuleID, []rules.RuleID, error) {
+func GetRuleIdsToRun(configFile *config.Config, selection RuleSelection) ([]rules.RuleID, []rules.RuleID, error) {
 	//To Baseline scan on all ruleIds
-	if opts.BaselineScan {
+	if selection.BaselineScan {
 		return GetAllStdRuleIDs(), configFile.GetCustomRuleIds(), nil
 	}
 	// If user has specified specific rules, just return those
-	if opts.Rules != "" {
-		standardRuleIds, customRuleIds := segregateRuleIds(configFile, opts.SpecificRuleIds())
+	if len(selection.Rules) > 0 {
+		standardRuleIds, customRuleIds := segregateRuleIds(configFile, selection.Rules)
 		return standardRuleIds, customRuleIds, nil
 	}
 	if configFile != nil {
-		if opts.CICDScan {
+		if selection.CICDScan {
 			standardRuleIds, customRuleIds := segregateRuleIds(configFile, configFile.GetCICDRuleIds())
 			return standardRuleIds, customRuleIds, nil
 		}
@@ -1,11 +1,13 @@
 package ruleset
 
 import (
+	"context"
+	"path/filepath"
 	"reflect"
 	"testing"
 
 	"github.com/certinia/asist/config"
-	"github.com/certinia/asist/parser/options"
+	"github.com/certinia/asist/files"
 	"github.com/certinia/asist/rules"
 )
 
@@ -24,14 +26,14 @@ func TestGetAllStdRuleIDs_StandardRuleIds(t *testing.T) {
 
 func TestGetRuleIdsToRun_BaselineEnabledAndConfigNil_returnAllStandardAndCustomRuleIds(t *testing.T) {
 	//Given
-	opts := options.Options{
+	selection := RuleSelection{
 		BaselineScan: true,
 	}
 	expectedStandardRuleIds := GetAllStdRuleIDs()
 	expectedCustomRuleIds := []rules.RuleID{}
 
 	//When
-	actualStandardRuleIds, actualCustomRuleIds, err := GetRuleIdsToRun(nil, &opts)
+	actualStandardRuleIds, actualCustomRuleIds, err := GetRuleIdsToRun(nil, selection)
 
 	//Then
 	if !reflect.DeepEqual(actualStandardRuleIds, expectedStandardRuleIds) {
@@ -47,7 +49,7 @@ func TestGetRuleIdsToRun_BaselineEnabledAndConfigNil_returnAllStandardAndCustomR
 
 func TestGetRuleIdsToRun_BaselineEnabledAndHasConfigCustomRules_returnAllStandardAndCustomRuleIds(t *testing.T) {
 	//Given
-	opts := options.Options{
+	selection := RuleSelection{
 		BaselineScan: true,
 	}
 
@@ -61,7 +63,7 @@ func TestGetRuleIdsToRun_BaselineEnabledAndHasConfigCustomRules_returnAllStandar
 	expectedCustomRuleIds := []rules.RuleID{"customRule1"}
 
 	//When
-	actualStandardRuleIds, actualCustomRuleIds, err := GetRuleIdsToRun(&configFile, &opts)
+	actualStandardRuleIds, actualCustomRuleIds, err := GetRuleIdsToRun(&configFile, selection)
 
 	//Then
 	if !reflect.DeepEqual(actualStandardRuleIds, expectedStandardRuleIds) {
@@ -77,14 +79,14 @@ func TestGetRuleIdsToRun_BaselineEnabledAndHasConfigCustomRules_returnAllStandar
 
 func TestGetRuleIdsToRun_BaselineDisabledAndHasSpecificRuleIds_returnSpecificRuleIds(t *testing.T) {
 	//Given
-	opts := options.Options{
+	selection := RuleSelection{
 		BaselineScan: false,
-		Rules:        "ApexClassNoSharing,XSSTooltip",
+		Rules:        []rules.RuleID{"ApexClassNoSharing", "XSSTooltip"},
 	}
 	expectedStandardRuleIds := []rules.RuleID{"ApexClassNoSharing", "XSSTooltip"}
 
 	//When
-	actualStandardRuleIds, actualCustomRuleIds, err := GetRuleIdsToRun(nil, &opts)
+	actualStandardRuleIds, actualCustomRuleIds, err := GetRuleIdsToRun(nil, selection)
 
 	//Then
 	if !reflect.DeepEqual(actualStandardRuleIds, expectedStandardRuleIds) {
@@ -100,7 +102,7 @@ func TestGetRuleIdsToRun_BaselineDisabledAndHasSpecificRuleIds_returnSpecificRul
 
 func TestGetRuleIdsToRun_BaselineDisabledAndHasCICDRuleIds_returnCicdRuleIds(t *testing.T) {
 	//Given
-	opts := options.Options{
+	selection := RuleSelection{
 		CICDScan: true,
 	}
 	configFile := config.Config{
@@ -112,7 +114,7 @@ func TestGetRuleIdsToRun_BaselineDisabledAndHasCICDRuleIds_returnCicdRuleIds(t *
 	expectedCicdRuleIds := []rules.RuleID{"ApexClassNoSharing"}
 
 	//When
-	actualCicdRuleIds, actualCustomRuleIds, err := GetRuleIdsToRun(&configFile, &opts)
+	actualCicdRuleIds, actualCustomRuleIds, err := GetRuleIdsToRun(&configFile, selection)
 
 	//Then
 	if !reflect.DeepEqual(actualCicdRuleIds, expectedCicdRuleIds) {
@@ -124,12 +126,12 @@ func TestGetRuleIdsToRun_BaselineDisabledAndHasCICDRuleIds_returnCicdRuleIds(t *
 	if err != nil {
 		t.Errorf("GetRuleIdsToRun method should not return error!")
 	}
-	opts.CICDScan = false
+	selection.CICDScan = false
 }
 
 func TestGetRuleIdsToRun_BaselineDisabledAndHasCustomRulesInCICDRuleIds_returnCicdRuleIdsDropsInvalidRuleIds(t *testing.T) {
 	//Given
-	opts := options.Options{
+	selection := RuleSelection{
 		CICDScan: true,
 	}
 	enable := true
@@ -170,7 +172,7 @@ func TestGetRuleIdsToRun_BaselineDisabledAndHasCustomRulesInCICDRuleIds_returnCi
 	expectedCicdCustomRuleIds := []rules.RuleID{"CustomRule1", "CustomRule2"}
 
 	//When
-	actualCicdStandardRuleIds, actualCicdCustomRuleIds, err := GetRuleIdsToRun(&configFile, &opts)
+	actualCicdStandardRuleIds, actualCicdCustomRuleIds, err := GetRuleIdsToRun(&configFile, selection)
 
 	//Then
 	if !reflect.DeepEqual(actualCicdStandardRuleIds, expectedCicdStandardRuleIds) {
@@ -187,7 +189,7 @@ func TestGetRuleIdsToRun_BaselineDisabledAndHasCustomRulesInCICDRuleIds_returnCi
 func TestGetRuleIdsToRun_BaselineDisabledAndConfigEnableAllStandardRulesEnabled_returnAllStandardRuleIds(t *testing.T) {
 	//Given
 	ENABLED_TRUE := true
-	opts := options.Options{
+	selection := RuleSelection{
 		BaselineScan: false,
 	}
 	configFile := config.Config{
@@ -201,7 +203,7 @@ func TestGetRuleIdsToRun_BaselineDisabledAndConfigEnableAllStandardRulesEnabled_
 	expectedStandardRuleIds := GetAllStdRuleIDs()
 
 	//When
-	actualStandardRuleIds, actualCustomRuleIds, err := GetRuleIdsToRun(&configFile, &opts)
+	actualStandardRuleIds, actualCustomRuleIds, err := GetRuleIdsToRun(&configFile, selection)
 
 	//Then
 	if !reflect.DeepEqual(actualStandardRuleIds, expectedStandardRuleIds) {
@@ -219,7 +221,7 @@ func TestGetRuleIdsToRun_BaselineDisabledAndConfigEnableAllStandardRulesEnabled_
 func TestGetRuleIdsToRun_BaselineDisabledAndConfigEnableAllStandardRulesDisabled_returnAllStandardRuleIds(t *testing.T) {
 	//Given
 	ENABLED_FALSE := false
-	opts := options.Options{
+	selection := RuleSelection{
 		BaselineScan: false,
 	}
 	configFile := config.Config{
@@ -231,7 +233,7 @@ func TestGetRuleIdsToRun_BaselineDisabledAndConfigEnableAllStandardRulesDisabled
 	}
 
 	//When
-	actualStandardRuleIds, actualCustomRuleIds, err := GetRuleIdsToRun(&configFile, &opts)
+	actualStandardRuleIds, actualCustomRuleIds, err := GetRuleIdsToRun(&configFile, selection)
 
 	//Then
 	if len(actualStandardRuleIds) != 0 {
@@ -247,12 +249,12 @@ func TestGetRuleIdsToRun_BaselineDisabledAndConfigEnableAllStandardRulesDisabled
 
 func TestGetRuleIdsToRun_OptionsAllDisabledAndConfigFileNil_returnAllStandardRuleIds(t *testing.T) {
 	//Given
-	opts := options.Options{}
+	selection := RuleSelection{}
 
 	expectedStandardRuleIds := GetAllStdRuleIDs()
 
 	//When
-	actualStandardRuleIds, actualCustomRuleIds, err := GetRuleIdsToRun(nil, &opts)
+	actualStandardRuleIds, actualCustomRuleIds, err := GetRuleIdsToRun(nil, selection)
 
 	//Then
 	if !reflect.DeepEqual(actualStandardRuleIds, expectedStandardRuleIds) {
@@ -268,12 +270,12 @@ func TestGetRuleIdsToRun_OptionsAllDisabledAndConfigFileNil_returnAllStandardRul
 
 func TestGetRuleIdsToRun_SpecificRuleHasInvalidRuleId_DropsInvalidRuleId(t *testing.T) {
 	//Given
-	opts := options.Options{
-		Rules: "InvalidId",
+	selection := RuleSelection{
+		Rules: []rules.RuleID{"InvalidId"},
 	}
 
 	//When
-	actualStandardRuleIds, actualCustomRuleIds, err := GetRuleIdsToRun(nil, &opts)
+	actualStandardRuleIds, actualCustomRuleIds, err := GetRuleIdsToRun(nil, selection)
 
 	//Then
 	if len(actualStandardRuleIds) != 0 {
@@ -289,8 +291,8 @@ func TestGetRuleIdsToRun_SpecificRuleHasInvalidRuleId_DropsInvalidRuleId(t *test
 
 func TestGetRuleIdsToRun_SpecificRuleHasCustomRuleId_ReturnsCustomRuleId(t *testing.T) {
 	//Given
-	opts := options.Options{
-		Rules: "CustomRule1",
+	selection := RuleSelection{
+		Rules: []rules.RuleID{"CustomRule1"},
 	}
 	enable := true
 	configFile := config.Config{
@@ -311,7 +313,7 @@ func TestGetRuleIdsToRun_SpecificRuleHasCustomRuleId_ReturnsCustomRuleId(t *test
 	expectedCustomRuleIds := []rules.RuleID{"CustomRule1"}
 
 	//When
-	actualStandardRuleIds, actualCustomRuleIds, err := GetRuleIdsToRun(&configFile, &opts)
+	actualStandardRuleIds, actualCustomRuleIds, err := GetRuleIdsToRun(&configFile, selection)
 
 	//Then
 	if len(actualStandardRuleIds) != 0 {
@@ -327,7 +329,7 @@ func TestGetRuleIdsToRun_SpecificRuleHasCustomRuleId_ReturnsCustomRuleId(t *test
 
 func TestGetRuleIdsToRun_OverrideRuleHasInvalidRuleId_ReturnsAllStandardRuleIds(t *testing.T) {
 	//Given
-	opts := options.Options{}
+	selection := RuleSelection{}
 
 	configFile := config.Config{
 		RuleOverrides: map[string]rules.RuleMetadataOverride{
@@ -338,7 +340,7 @@ func TestGetRuleIdsToRun_OverrideRuleHasInvalidRuleId_ReturnsAllStandardRuleIds(
 	expectedStandardRuleIds := GetAllStdRuleIDs()
 
 	//When
-	actualStandardRuleIds, actualCustomRuleIds, err := GetRuleIdsToRun(&configFile, &opts)
+	actualStandardRuleIds, actualCustomRuleIds, err := GetRuleIdsToRun(&configFile, selection)
 	//Then
 	if !reflect.DeepEqual(actualStandardRuleIds, expectedStandardRuleIds) {
 		t.Errorf("%s Actual: %+v, Expected: %+v", "Standard ruleIds are mismatched!", actualStandardRuleIds, expectedStandardRuleIds)
@@ -372,7 +374,7 @@ func TestCreateRules_WhenStandardAndCustomRuleIds_ReturnRules(t *testing.T) {
 	}
 	expectedRulesCount := 2
 	//When
-	actualRules, err := CreateAndOverrideRules(standardRuleIds, customRuleIds, &configFile)
+	actualRules, err := CreateAndOverrideRules(standardRuleIds, customRuleIds, &configFile, false)
 
 	//Then
 	if len(actualRules) != expectedRulesCount {
@@ -397,7 +399,7 @@ func TestCreateRules_WhenStandardRulesOverrided_ReturnOverridedRules(t *testing.
 	expectedRulesSeverity := rules.Severity("Low")
 
 	//When
-	actualRules, err := CreateAndOverrideRules(standardRuleIds, customRuleIds, &configFile)
+	actualRules, err := CreateAndOverrideRules(standardRuleIds, customRuleIds, &configFile, false)
 
 	//Then
 	if (*actualRules[0]).GetMetadata().Severity != expectedRulesSeverity {
@@ -415,7 +417,7 @@ func TestCreateRules_WhenStandardRules_ReturnOverridedRules(t *testing.T) {
 	expectedRulesCount := 1
 
 	//When
-	actualRules, err := CreateAndOverrideRules(standardRuleIds, customRuleIds, nil)
+	actualRules, err := CreateAndOverrideRules(standardRuleIds, customRuleIds, nil, false)
 
 	//Then
 	if len(actualRules) != expectedRulesCount {
@@ -433,7 +435,7 @@ func TestCreateRules_WhenStandardRuleIdInvalid_ReturnsNoRules(t *testing.T) {
 	customRuleIds := []rules.RuleID{}
 
 	//When
-	actualRules, err := CreateAndOverrideRules(standardRuleIds, customRuleIds, nil)
+	actualRules, err := CreateAndOverrideRules(standardRuleIds, customRuleIds, nil, false)
 
 	//Then
 	if len(actualRules) != 0 {
@@ -443,3 +445,30 @@ func TestCreateRules_WhenStandardRuleIdInvalid_ReturnsNoRules(t *testing.T) {
 		t.Errorf("CreateAndOverrideRules method should not return error")
 	}
 }
+
+func TestCreateAndOverrideRules_WhenContextIsCanceled_StandardRulesStopBeforeScanningLines(t *testing.T) {
+	//Given
+	ruleInstances, err := CreateAndOverrideRules(GetAllStdRuleIDs(), nil, nil, true)
+	if err != nil {
+		t.Fatalf("Should not return any error! %v", err)
+	}
+	filePaths, _ := filepath.Glob("../integrationtests/src/*/*")
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	for _, filePath := range filePaths {
+		fileMaster, err := files.Read(filePath)
+		if err != nil {
+			continue
+		}
+		for _, rule := range ruleInstances {
+			//When
+			actualResult := (*rule).Run(ctx, *fileMaster)
+
+			//Then
+			if len(actualResult) != 0 {
+				t.Errorf("Rule %s should not scan %s once ctx is done. Actual: %+v", (*rule).GetMetadata().ID, filePath, actualResult)
+			}
+		}
+	}
+}
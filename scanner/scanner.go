@@ -1,124 +1,153 @@
 package scanner
 
 import (
+	"context"
 	"errors"
 	"fmt"
-	"io/fs"
+	"log"
+	"os"
 
+	"github.com/certinia/asist/cache"
 	"github.com/certinia/asist/config"
 	"github.com/certinia/asist/debugger"
+	"github.com/certinia/asist/engine"
 	"github.com/certinia/asist/errorhandler"
 	"github.com/certinia/asist/files"
+	"github.com/certinia/asist/files/gitdiff"
 	"github.com/certinia/asist/finding"
 	"github.com/certinia/asist/message"
 	"github.com/certinia/asist/output"
 	"github.com/certinia/asist/parser/options"
-	"github.com/certinia/asist/regexrulehelper"
 	"github.com/certinia/asist/rules"
-	"github.com/certinia/asist/ruleset"
 )
 
 var Version = ""
 
 /**
-*	LoadResources - Method will load and setup the required resources for scan
+*	LoadResources - Method will load and setup the required resources for scan.
+*	With --since, it also returns the lines changed since the git ref, nil otherwise
  */
-func LoadResources() ([]string, []*rules.Rule, error) {
+func LoadResources() ([]string, []*rules.Rule, gitdiff.ChangedLines, error) {
 	opts := options.Initilize()
 	debugger.Debug("start")
 	output.DisplayVersion(Version)
+	output.ToolVersion = Version
 
 	configFile, configErr := loadConfigFile(opts)
 	if configErr != nil {
-		return nil, nil, configErr
+		return nil, nil, nil, configErr
 	}
-	rules, rulesErr := loadRules(opts, configFile)
+	scanEngine, rulesErr := engine.NewScanner(configFile, NewScanOptions(opts))
 	if rulesErr != nil {
-		return nil, nil, rulesErr
+		return nil, nil, nil, rulesErr
 	}
+	debugger.Debug("created rules")
 	// List rules and exit if requested
-	output.ListRules(rules)
+	output.ListRules(scanEngine.Rules())
 
-	paths, pathsErr := loadAndFilterFilePath(configFile)
+	paths, changedLines, pathsErr := loadAndFilterFilePath(configFile)
 	if pathsErr != nil {
-		return nil, nil, pathsErr
+		return nil, nil, nil, pathsErr
+	}
+	return paths, scanEngine.Rules(), changedLines, nil
+}
+
+/**
+ * NewScanOptions - method used to create the options of the scan engine from the command line options
+ */
+func NewScanOptions(opts *options.Options) engine.ScanOptions {
+	scanOptions := engine.ScanOptions{
+		CICDScan:     opts.CICDScan,
+		BaselineScan: opts.BaselineScan,
+		Jobs:         opts.Jobs,
+		FileTimeout:  opts.FileTimeout,
+		ParseOptions: files.ParseOptions{MaxLineLength: opts.MaxLineLength, ChunkLongLines: opts.ChunkLongLines},
+		ReadFile:     readFile,
 	}
-	return paths, rules, nil
+	if opts.Rules != "" {
+		scanOptions.Rules = opts.SpecificRuleIds()
+	}
+	return scanOptions
 }
 
 /**
  * RunRulesOnFiles - method used to run active rules (standard or custom) on the file paths provided by user.
+ *	The order of the final results always follows the order of filePaths. The findings outside of the changed lines are dropped,
+ *	unless changedLines is nil.
  */
-func RunRulesOnFiles(filePaths []string, rules []*rules.Rule) (*finding.Output, error) {
-	var finalResult finding.Output
-	allfindings := []finding.Finding{}
-	for _, path := range filePaths {
-		debugger.Debug(fmt.Sprintf("checking if eligible to scan file %s", path))
-		rulesToRun := getValidRulesForFile(path, rules)
-		if len(rulesToRun) == 0 {
-			debugger.Debug(fmt.Sprintf("file is not eligible to scan for enabled rules %s", path))
-			continue
+func RunRulesOnFiles(ctx context.Context, filePaths []string, rules []*rules.Rule, changedLines gitdiff.ChangedLines) (*finding.Output, error) {
+	scanOptions := NewScanOptions(options.GetOptions())
+	if cacheDir := options.GetCacheDir(); cacheDir != "" {
+		scanOptions.Cache = openCache(cacheDir, rules, scanOptions)
+	}
+	scanEngine := engine.NewScannerWithRules(config.GetConfigInstance(), rules, scanOptions)
+	finalResult, err := scanEngine.ScanFiles(ctx, filePaths)
+	if errors.Is(err, context.Canceled) || errors.Is(err, context.DeadlineExceeded) {
+		return nil, errorhandler.NewUserError(message.GetScanCanceledError(err))
+	}
+	if err != nil {
+		return nil, err
+	}
+	if scanOptions.Cache != nil {
+		// The cache only speeds up the next scans, failing to write it does not fail this scan
+		if err := scanOptions.Cache.Write(); err != nil {
+			log.Println(message.GetCacheWriteWarning(options.GetCacheDir(), err))
 		}
-		debugger.Debug(fmt.Sprintf("file is eligible to scan for enabled rules %s", path))
-		findings, err := runRulesOnFile(rulesToRun, path)
-		if err != nil {
+		debugger.Debug("wrote cache")
+	}
+	if changedLines != nil {
+		finalResult = engine.FilterChangedFindings(finalResult, changedLines)
+	}
+	if options.IsBlame() {
+		if err := engine.AddBlame(finalResult.Results, options.GetPathToScan()); err != nil {
 			return nil, err
 		}
-		allfindings = append(allfindings, findings...)
+		debugger.Debug("added blame to findings")
 	}
-	finalResult.Count = len(allfindings)
-	finalResult.Results = allfindings
-	return &finalResult, nil
+	return finalResult, nil
 }
 
 /**
- * runRulesOnFile - method used to scan a particular file using the enabled rules and
- *	returns finding for particular file
+ * openCache - method used to open the cache of the findings for the binary version, the effective rules, the config and the options
+ *	changing the findings. The cache is not used if its key cannot be created.
  */
-func runRulesOnFile(rulesToRun []*rules.Rule, fileName string) ([]finding.Finding, error) {
-	debugger.Debug(fmt.Sprintf("running rules on %s", fileName))
-	allFindings := []finding.Finding{}
-	fileMaster, err := files.Read(fileName)
+func openCache(cacheDir string, ruleInstances []*rules.Rule, scanOptions engine.ScanOptions) *cache.Cache {
+	key, err := cache.CreateKey(getBinaryVersion(), output.GetRulesMetadata(ruleInstances), config.GetConfigInstance(), scanOptions.BaselineScan, scanOptions.ParseOptions)
 	if err != nil {
-		if errors.Is(err, fs.ErrNotExist) {
-			return nil, nil
-		}
-		return nil, errorhandler.NewInternalError(message.GetFileReadError(fileName, err))
+		log.Println(message.GetCacheKeyWarning(err))
+		return nil
 	}
-	debugger.Debug(fmt.Sprintf("read file %s into memory", fileName))
+	debugger.Debug("opened cache")
+	return cache.Open(cacheDir, key)
+}
 
-	for _, rule := range rulesToRun {
-		ruleMetadata := (*rule).GetMetadata()
-		//Search result in master file using pattern(Regex)
-		currentRuleOccurrence := (*rule).Run(*fileMaster)
-		for _, occurrence := range currentRuleOccurrence {
-			allFindings = append(allFindings, finding.Finding{
-				Occurrence:   occurrence,
-				ID:           ruleMetadata.ID,
-				Name:         ruleMetadata.Name,
-				Description:  ruleMetadata.Description,
-				Severity:     ruleMetadata.Severity,
-				RuleCategory: ruleMetadata.RuleCategory,
-			})
-		}
-		debugger.Debug(fmt.Sprintf("ran rule %s on %s", ruleMetadata.ID, fileName))
+/**
+ * getBinaryVersion - method used to get the version of the binary for the cache key.
+ *	Builds without version, e.g. during development, use the modification time of the binary instead.
+ */
+func getBinaryVersion() string {
+	if Version != "" {
+		return Version
 	}
-
-	return allFindings, nil
+	executablePath, err := os.Executable()
+	if err != nil {
+		return ""
+	}
+	executableInfo, err := os.Stat(executablePath)
+	if err != nil {
+		return ""
+	}
+	return fmt.Sprintf("%s-%d", executableInfo.ModTime(), executableInfo.Size())
 }
 
 /**
-* getValidRulesForFile - Method will return rules which are valid for file.
+ * readFile - method used to read a file from disk, or from the standard input when scanning stdin with a virtual file name
  */
-func getValidRulesForFile(path string, ruleInstances []*rules.Rule) []*rules.Rule {
-	rulesToRun := []*rules.Rule{}
-	for _, rule := range ruleInstances {
-		metadata := (*rule).GetMetadata()
-		if regexrulehelper.RunIncludeExcludePatternsOnFile(path, *metadata) {
-			rulesToRun = append(rulesToRun, rule)
-		}
+func readFile(fileName string, parseOptions files.ParseOptions) (*files.File, error) {
+	if options.IsStdin() {
+		return files.ParseWithOptions(fileName, os.Stdin, parseOptions)
 	}
-	return rulesToRun
+	return files.ReadWithOptions(fileName, parseOptions)
 }
 
 func loadConfigFile(opts *options.Options) (*config.Config, error) {
@@ -137,35 +166,20 @@ func loadConfigFile(opts *options.Options) (*config.Config, error) {
 	return configFile, nil
 }
 
-func loadRules(opts *options.Options, configFile *config.Config) ([]*rules.Rule, error) {
-	standardRuleIds, customRuleIds, ruleIdsErr := ruleset.GetRuleIdsToRun(configFile, opts)
-	if ruleIdsErr != nil {
-		return nil, ruleIdsErr
-	}
-	debugger.Debug("created list of rules to run")
-
-	//Create rules for Ids
-	rules, ruleErr := ruleset.CreateAndOverrideRules(standardRuleIds, customRuleIds, configFile)
-	if ruleErr != nil {
-		return nil, ruleErr
+func loadAndFilterFilePath(configFile *config.Config) ([]string, gitdiff.ChangedLines, error) {
+	// The content read from stdin is the only file to scan, identified by its virtual name
+	if options.IsStdin() {
+		return configFile.FilterExcludedFilesAndFolders([]string{options.GetStdinFilename()}), nil, nil
 	}
-	debugger.Debug("created rules")
-	return rules, nil
-}
-
-func loadAndFilterFilePath(configFile *config.Config) ([]string, error) {
-	fileOptions := files.FileOptions{
-		RootPath:        options.GetPathToScan(),
-		DontForceIgnore: configFile != nil && configFile.DontForceIgnore,
-		DontGitIgnore:   configFile != nil && configFile.DontGitIgnore,
-	}
-	// Get all file paths to scan
-	paths, pathErr := files.GetAllFilePaths(fileOptions)
+	// Get all file paths to scan, without the files and folders excluded by the config or the ignore files.
+	// Only keep files changed since the git ref, if requested
+	paths, changedLines, pathErr := engine.ListFiles(configFile, []string{options.GetPathToScan()}, options.GetSince())
 	if pathErr != nil {
-		return nil, pathErr
+		return nil, nil, pathErr
 	}
-	// Excludes files and folders from user provided directory using yaml feature 'excludefilesandfolders'
-	paths = configFile.FilterExcludedFilesAndFolders(paths)
 	debugger.Debug("enumerated files to scan")
-	return paths, nil
+	if since := options.GetSince(); since != "" {
+		debugger.Debug(fmt.Sprintf("filtered files changed since %s", since))
+	}
+	return paths, changedLines, nil
 }
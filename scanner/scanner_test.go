@@ -1,9 +1,15 @@
 package scanner
 
 import (
+	"context"
+	"fmt"
+	"os"
+	"path/filepath"
 	"reflect"
 	"testing"
 
+	"github.com/certinia/asist/files"
+	"github.com/certinia/asist/files/gitdiff"
 	"github.com/certinia/asist/finding"
 	"github.com/certinia/asist/rules"
 	testrule "github.com/certinia/asist/scanner/testData"
@@ -44,9 +50,11 @@ func TestRunRulesOnFiles_WhenFileExistAndRulesIsValidToRunOnFile_ReturnsFindings
 			Occurrence:   mockData[0],
 		},
 	}
+	testFile, _ := files.Read(filepaths[0])
+	finding.SetFingerprints(expectedResult, testFile)
 
 	//When
-	actualResult, err := RunRulesOnFiles(filepaths, ruleInstances)
+	actualResult, err := RunRulesOnFiles(context.Background(), filepaths, ruleInstances, nil)
 
 	//Then
 	if actualResult.Count != len(expectedResult) {
@@ -60,6 +68,28 @@ func TestRunRulesOnFiles_WhenFileExistAndRulesIsValidToRunOnFile_ReturnsFindings
 	}
 }
 
+func TestRunRulesOnFiles_WhenChangedLinesAreGiven_DropsFindingsOutsideOfThem(t *testing.T) {
+	//Given
+	filepaths := []string{"./testData/testFile.cls"}
+	testRule := testrule.NewTestRule(rules.RuleMetadata{ID: "testId", IncludePattern: "\\.cls$"})
+	testrule.SetMockData([]rules.Occurrence{
+		{FileName: "./testData/testFile.cls", LineNumber: 1},
+		{FileName: "./testData/testFile.cls", LineNumber: 3},
+	})
+	changedLines := gitdiff.ChangedLines{"./testData/testFile.cls": {{Start: 3, End: 3}}}
+
+	//When
+	actualResult, err := RunRulesOnFiles(context.Background(), filepaths, []*rules.Rule{&testRule}, changedLines)
+
+	//Then
+	if err != nil {
+		t.Fatalf("RunRuleOnFiles method should not return error! %v", err)
+	}
+	if actualResult.Count != 1 || actualResult.Results[0].Occurrence.LineNumber != 3 {
+		t.Errorf("Only the finding on the changed line should be kept. Actual %+v", actualResult.Results)
+	}
+}
+
 func TestRunRulesOnFiles_WhenFileExistAndRulesIsInValidToRunOnFile_ReturnsEmptyResult(t *testing.T) {
 	//Given
 	filepaths := []string{"./testData/testFile.cls"}
@@ -83,7 +113,7 @@ func TestRunRulesOnFiles_WhenFileExistAndRulesIsInValidToRunOnFile_ReturnsEmptyR
 	expectedResultCount := 0
 
 	//When
-	actualResult, err := RunRulesOnFiles(filepaths, ruleInstances)
+	actualResult, err := RunRulesOnFiles(context.Background(), filepaths, ruleInstances, nil)
 
 	//Then
 	if len((*actualResult).Results) != expectedResultCount {
@@ -93,3 +123,46 @@ func TestRunRulesOnFiles_WhenFileExistAndRulesIsInValidToRunOnFile_ReturnsEmptyR
 		t.Errorf("RunRuleOnFiles method should not return error!")
 	}
 }
+
+type fileNameRule struct {
+	metadata rules.RuleMetadata
+}
+
+func (r *fileNameRule) GetMetadata() *rules.RuleMetadata {
+	return &r.metadata
+}
+
+func (r *fileNameRule) Run(ctx context.Context, fileToScan files.File) []rules.Occurrence {
+	return []rules.Occurrence{{FileName: fileToScan.FileName, LineNumber: len(fileToScan.Lines)}}
+}
+
+func TestRunRulesOnFiles_WhenManyFiles_ReturnsFindingsInFilePathOrder(t *testing.T) {
+	//Given
+	tempDir := t.TempDir()
+	filepaths := []string{}
+	for index := 0; index < 100; index++ {
+		path := filepath.Join(tempDir, fmt.Sprintf("file%d.cls", index))
+		if err := os.WriteFile(path, []byte("public class Test {\n}\n"), 0600); err != nil {
+			t.Fatal(err)
+		}
+		filepaths = append(filepaths, path)
+	}
+	var rule rules.Rule = &fileNameRule{metadata: rules.RuleMetadata{ID: "fileNameRule"}}
+	ruleInstances := []*rules.Rule{&rule}
+
+	//When
+	actualResult, err := RunRulesOnFiles(context.Background(), filepaths, ruleInstances, nil)
+
+	//Then
+	if err != nil {
+		t.Fatalf("RunRuleOnFiles method should not return error! %v", err)
+	}
+	if actualResult.Count != len(filepaths) {
+		t.Fatalf("Occurrences count mismatched.\n Actual %v, Expected %v", actualResult.Count, len(filepaths))
+	}
+	for index, path := range filepaths {
+		if actualResult.Results[index].Occurrence.FileName != path {
+			t.Errorf("Findings are not in file path order at index %d.\n Actual %v, Expected %v", index, actualResult.Results[index].Occurrence.FileName, path)
+		}
+	}
+}
@@ -1,6 +1,8 @@
 package testrule
 
 import (
+	"context"
+
 	"github.com/certinia/asist/files"
 	"github.com/certinia/asist/rules"
 )
@@ -21,7 +23,7 @@ func (r *test) GetMetadata() *rules.RuleMetadata {
 	return &r.metadata
 }
 
-func (r *test) Run(fileToScan files.File) []rules.Occurrence {
+func (r *test) Run(ctx context.Context, fileToScan files.File) []rules.Occurrence {
 	return mockData
 }
 
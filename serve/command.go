@@ -0,0 +1,59 @@
+package serve
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net"
+	"net/http"
+	"os"
+	"os/signal"
+	"time"
+
+	"github.com/certinia/asist/errorhandler"
+	"github.com/certinia/asist/message"
+	"github.com/certinia/asist/parser/options"
+	"github.com/certinia/asist/scanner"
+)
+
+// Time given to the requests in progress to complete when the server is stopped
+const shutdownTimeout = 10 * time.Second
+
+// Command is the asist serve subcommand, it runs the JSON API until interrupted
+type Command struct {
+	Listen string `long:"listen" default:"127.0.0.1:8765" description:"Address the server listens on. Only bind to a public interface behind an authenticating proxy"`
+}
+
+/**
+ * Execute - method used to load the config and the rules, then to serve the JSON API until interrupted
+ */
+func (c *Command) Execute(args []string) error {
+	opts := options.GetOptions()
+	server, err := NewServer(opts.ConfigFile, scanner.NewScanOptions(opts))
+	if err != nil {
+		return err
+	}
+	listener, err := net.Listen("tcp", c.Listen)
+	if err != nil {
+		return errorhandler.NewUserError(message.GetListenError(c.Listen, err))
+	}
+	httpServer := &http.Server{Handler: server.Handler(), ReadHeaderTimeout: 10 * time.Second}
+
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt)
+	defer stop()
+	isShutdown := make(chan struct{})
+	go func() {
+		defer close(isShutdown)
+		<-ctx.Done()
+		shutdownCtx, cancel := context.WithTimeout(context.Background(), shutdownTimeout)
+		defer cancel()
+		httpServer.Shutdown(shutdownCtx)
+	}()
+	fmt.Fprintf(os.Stderr, "%s\n", message.GetServerStartedInfo(listener.Addr().String()))
+	if err := httpServer.Serve(listener); !errors.Is(err, http.ErrServerClosed) {
+		return errorhandler.NewInternalError(message.GetServerError(err))
+	}
+	// Serve returns as soon as the shutdown starts, wait for the requests in progress
+	<-isShutdown
+	return nil
+}
@@ -0,0 +1,159 @@
+// Package serve runs ASIST as a local HTTP service with a JSON API, so clients scan content without starting a process
+// and parsing the config on each request. The rules are created once and kept until the config is reloaded.
+package serve
+
+import (
+	"encoding/json"
+	"net/http"
+	"strings"
+	"sync"
+
+	"github.com/certinia/asist/config"
+	"github.com/certinia/asist/engine"
+	"github.com/certinia/asist/files"
+	"github.com/certinia/asist/finding"
+	"github.com/certinia/asist/message"
+	"github.com/certinia/asist/output"
+)
+
+// maxRequestSize is the maximum size in bytes of a request body, i.e. of the content of a file to scan
+const maxRequestSize = 64 << 20
+
+// ScanRequest is the body of POST /scan, the virtual path selects the rules to run and is reported in the findings
+type ScanRequest struct {
+	Path    string `json:"Path"`
+	Content string `json:"Content"`
+}
+
+// ErrorResponse is the body of the responses of failed requests
+type ErrorResponse struct {
+	Error string `json:"Error"`
+}
+
+// Server handles the requests of the JSON API, it can handle several requests at once
+type Server struct {
+	configFilePath string
+	scanOptions    engine.ScanOptions
+	mutex          sync.RWMutex
+	configFile     *config.Config
+	scanEngine     *engine.Scanner
+}
+
+/**
+ * NewServer - method used to create a server scanning with the config file and the scan options, the config file is optional
+ */
+func NewServer(configFilePath string, scanOptions engine.ScanOptions) (*Server, error) {
+	s := &Server{configFilePath: configFilePath, scanOptions: scanOptions}
+	if err := s.loadConfig(); err != nil {
+		return nil, err
+	}
+	return s, nil
+}
+
+/**
+ * Handler - method used to get the handler of the endpoints of the JSON API
+ */
+func (s *Server) Handler() http.Handler {
+	mux := http.NewServeMux()
+	mux.HandleFunc("POST /scan", s.handleScan)
+	mux.HandleFunc("GET /rules", s.handleRules)
+	mux.HandleFunc("POST /reload-config", s.handleReloadConfig)
+	return mux
+}
+
+/**
+ * loadConfig - method used to parse the config file and to create its rules. The current rules are kept if it fails.
+ */
+func (s *Server) loadConfig() error {
+	configFile, err := config.Load(s.configFilePath)
+	if err != nil {
+		return err
+	}
+	scanEngine, err := engine.NewScanner(configFile, s.scanOptions)
+	if err != nil {
+		return err
+	}
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+	s.configFile = configFile
+	s.scanEngine = scanEngine
+	return nil
+}
+
+/**
+ * getScanner - method used to get the scanner of the current config, requests keep the scanner they started with during a reload
+ */
+func (s *Server) getScanner() *engine.Scanner {
+	_, scanEngine := s.getConfigAndScanner()
+	return scanEngine
+}
+
+/**
+ * getConfigAndScanner - method used to get the current config and its scanner together, so a reload cannot mix them up
+ */
+func (s *Server) getConfigAndScanner() (*config.Config, *engine.Scanner) {
+	s.mutex.RLock()
+	defer s.mutex.RUnlock()
+	return s.configFile, s.scanEngine
+}
+
+/**
+ * handleScan - method used to scan the content of a file sent with its virtual path, and to respond with its findings.
+ *	A path excluded by the config has no findings, like when scanning its folder.
+ */
+func (s *Server) handleScan(w http.ResponseWriter, r *http.Request) {
+	var scanRequest ScanRequest
+	if err := json.NewDecoder(http.MaxBytesReader(w, r.Body, maxRequestSize)).Decode(&scanRequest); err != nil {
+		writeError(w, http.StatusBadRequest, message.GetInvalidRequestError(err))
+		return
+	}
+	if strings.TrimSpace(scanRequest.Path) == "" {
+		writeError(w, http.StatusBadRequest, message.GetMissingScanPathError())
+		return
+	}
+	configFile, scanEngine := s.getConfigAndScanner()
+	if len(configFile.FilterExcludedFilesAndFolders([]string{scanRequest.Path})) == 0 {
+		writeJSON(w, http.StatusOK, &finding.Output{Results: []finding.Finding{}})
+		return
+	}
+	fileMaster, err := files.ParseWithOptions(scanRequest.Path, strings.NewReader(scanRequest.Content), scanEngine.ParseOptions())
+	if err != nil {
+		writeError(w, http.StatusInternalServerError, message.GetFileReadError(scanRequest.Path, err))
+		return
+	}
+	fileOutput := scanEngine.ScanFile(r.Context(), fileMaster)
+	if r.Context().Err() != nil {
+		return
+	}
+	writeJSON(w, http.StatusOK, &finding.Output{Count: len(fileOutput.Results), Results: fileOutput.Results, Diagnostics: fileOutput.Diagnostics})
+}
+
+/**
+ * handleRules - method used to respond with the metadata of the rules run by the scans, as listed by --list-rules
+ */
+func (s *Server) handleRules(w http.ResponseWriter, r *http.Request) {
+	writeJSON(w, http.StatusOK, output.GetRulesMetadata(s.getScanner().Rules()))
+}
+
+/**
+ * handleReloadConfig - method used to parse the config file again and to respond with the metadata of its rules.
+ *	The current rules are kept if the config is invalid.
+ */
+func (s *Server) handleReloadConfig(w http.ResponseWriter, r *http.Request) {
+	// The config file is read by the server, so an invalid config is an error of the server and not of the request
+	if err := s.loadConfig(); err != nil {
+		writeError(w, http.StatusInternalServerError, err.Error())
+		return
+	}
+	writeJSON(w, http.StatusOK, output.GetRulesMetadata(s.getScanner().Rules()))
+}
+
+func writeJSON(w http.ResponseWriter, statusCode int, body interface{}) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(statusCode)
+	json.NewEncoder(w).Encode(body)
+}
+
+func writeError(w http.ResponseWriter, statusCode int, msg string) {
+	writeJSON(w, statusCode, ErrorResponse{Error: strings.TrimSpace(msg)})
+}
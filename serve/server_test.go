@@ -0,0 +1,134 @@
+package serve
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/certinia/asist/engine"
+	"github.com/certinia/asist/finding"
+	"github.com/certinia/asist/rules"
+)
+
+func writeConfig(t *testing.T, path string, severity string) {
+	content := "ruleoverrides:\n  ApexClassNoSharing:\n    severity: " + severity + "\n"
+	if err := os.WriteFile(path, []byte(content), 0600); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func createServer(t *testing.T, configFilePath string) http.Handler {
+	server, err := NewServer(configFilePath, engine.ScanOptions{Rules: []rules.RuleID{"ApexClassNoSharing"}})
+	if err != nil {
+		t.Fatalf("Should not return any error! %v", err)
+	}
+	return server.Handler()
+}
+
+func scan(t *testing.T, handler http.Handler, body string) *httptest.ResponseRecorder {
+	recorder := httptest.NewRecorder()
+	handler.ServeHTTP(recorder, httptest.NewRequest(http.MethodPost, "/scan", strings.NewReader(body)))
+	return recorder
+}
+
+func TestHandleScan_WhenContentHasFindings_RespondsWithFindings(t *testing.T) {
+	//Given
+	handler := createServer(t, "")
+
+	//When
+	recorder := scan(t, handler, `{"Path": "classes/Foo.cls", "Content": "public class Foo {\n}\n"}`)
+
+	//Then
+	var scanOutput finding.Output
+	if err := json.Unmarshal(recorder.Body.Bytes(), &scanOutput); err != nil || recorder.Code != http.StatusOK {
+		t.Fatalf("Expected a scan output. Actual %d: %s", recorder.Code, recorder.Body.String())
+	}
+	if scanOutput.Count != 1 || scanOutput.Results[0].ID != "ApexClassNoSharing" || scanOutput.Results[0].Occurrence.FileName != "classes/Foo.cls" {
+		t.Errorf("Expected a finding in the virtual path. Actual: %+v", scanOutput)
+	}
+}
+
+func TestHandleScan_WhenPathIsExcluded_RespondsWithoutFindings(t *testing.T) {
+	//Given
+	configFilePath := filepath.Join(t.TempDir(), ".asist.yaml")
+	if err := os.WriteFile(configFilePath, []byte("excludefilesandfolders:\n  - generated/\n"), 0600); err != nil {
+		t.Fatal(err)
+	}
+	handler := createServer(t, configFilePath)
+
+	//When
+	recorder := scan(t, handler, `{"Path": "force-app/generated/Foo.cls", "Content": "public class Foo {\n}\n"}`)
+
+	//Then
+	var scanOutput finding.Output
+	if err := json.Unmarshal(recorder.Body.Bytes(), &scanOutput); err != nil || recorder.Code != http.StatusOK {
+		t.Fatalf("Expected a scan output. Actual %d: %s", recorder.Code, recorder.Body.String())
+	}
+	if scanOutput.Count != 0 || scanOutput.Results == nil || len(scanOutput.Results) != 0 {
+		t.Errorf("Expected no findings in an excluded path. Actual: %+v", scanOutput)
+	}
+}
+
+func TestHandleScan_WhenPathIsMissing_RespondsBadRequest(t *testing.T) {
+	//Given
+	handler := createServer(t, "")
+
+	//When
+	recorder := scan(t, handler, `{"Content": "public class Foo {\n}\n"}`)
+
+	//Then
+	if recorder.Code != http.StatusBadRequest || !strings.Contains(recorder.Body.String(), `"Error"`) {
+		t.Errorf("Expected a bad request error. Actual %d: %s", recorder.Code, recorder.Body.String())
+	}
+}
+
+func TestHandleReloadConfig_WhenConfigChanged_ScansWithNewConfig(t *testing.T) {
+	//Given
+	configFilePath := filepath.Join(t.TempDir(), ".asist.yaml")
+	writeConfig(t, configFilePath, "Low")
+	handler := createServer(t, configFilePath)
+	writeConfig(t, configFilePath, "High")
+
+	//When
+	reloadRecorder := httptest.NewRecorder()
+	handler.ServeHTTP(reloadRecorder, httptest.NewRequest(http.MethodPost, "/reload-config", nil))
+	rulesRecorder := httptest.NewRecorder()
+	handler.ServeHTTP(rulesRecorder, httptest.NewRequest(http.MethodGet, "/rules", nil))
+
+	//Then
+	if reloadRecorder.Code != http.StatusOK {
+		t.Fatalf("Should reload the config. Actual %d: %s", reloadRecorder.Code, reloadRecorder.Body.String())
+	}
+	var rulesMetadata []rules.RuleMetadata
+	if err := json.Unmarshal(rulesRecorder.Body.Bytes(), &rulesMetadata); err != nil {
+		t.Fatalf("Expected the rules metadata. Actual: %s", rulesRecorder.Body.String())
+	}
+	if len(rulesMetadata) != 1 || rulesMetadata[0].Severity != rules.SeverityHigh {
+		t.Errorf("Expected the severity of the reloaded config. Actual: %+v", rulesMetadata)
+	}
+}
+
+func TestHandleReloadConfig_WhenConfigIsInvalid_KeepsCurrentRules(t *testing.T) {
+	//Given
+	configFilePath := filepath.Join(t.TempDir(), ".asist.yaml")
+	writeConfig(t, configFilePath, "Low")
+	handler := createServer(t, configFilePath)
+	os.WriteFile(configFilePath, []byte("invalid"), 0600)
+
+	//When
+	reloadRecorder := httptest.NewRecorder()
+	handler.ServeHTTP(reloadRecorder, httptest.NewRequest(http.MethodPost, "/reload-config", nil))
+	scanRecorder := scan(t, handler, `{"Path": "classes/Foo.cls", "Content": "public class Foo {\n}\n"}`)
+
+	//Then
+	if reloadRecorder.Code != http.StatusInternalServerError {
+		t.Errorf("Expected an error. Actual %d: %s", reloadRecorder.Code, reloadRecorder.Body.String())
+	}
+	if !strings.Contains(scanRecorder.Body.String(), `"Severity":"Low"`) {
+		t.Errorf("Expected the findings of the previous config. Actual: %s", scanRecorder.Body.String())
+	}
+}
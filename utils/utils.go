@@ -43,3 +43,14 @@ func IsFileExists(path string) bool {
 	_, err := os.Stat(path)
 	return !errors.Is(err, fs.ErrNotExist)
 }
+
+/**
+* IsTerminal - method used to check a given file is a terminal, e.g. to print colored text on the standard output
+ */
+func IsTerminal(file *os.File) bool {
+	info, err := file.Stat()
+	if err != nil {
+		return false
+	}
+	return info.Mode()&os.ModeCharDevice != 0
+}
@@ -0,0 +1,41 @@
+package watch
+
+import (
+	"context"
+	"os"
+	"os/signal"
+
+	"github.com/certinia/asist/config"
+	"github.com/certinia/asist/engine"
+	"github.com/certinia/asist/parser/options"
+	"github.com/certinia/asist/scanner"
+)
+
+// Command is the asist watch subcommand, it scans a file or folder again whenever its files change until interrupted
+type Command struct {
+	Args struct {
+		Path string `positional-arg-name:"path" description:"File or folder to watch"`
+	} `positional-args:"yes" required:"yes"`
+}
+
+/**
+ * Execute - method used to scan the path, then to write the findings added and resolved by each change of its files
+ */
+func (c *Command) Execute(args []string) error {
+	opts := options.GetOptions()
+	configFile, err := config.Load(opts.ConfigFile)
+	if err != nil {
+		return err
+	}
+	scanEngine, err := engine.NewScanner(configFile, scanner.NewScanOptions(opts))
+	if err != nil {
+		return err
+	}
+	watcher, err := NewWatcher(scanEngine, configFile, c.Args.Path, os.Stdout)
+	if err != nil {
+		return err
+	}
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt)
+	defer stop()
+	return watcher.Run(ctx)
+}
@@ -0,0 +1,282 @@
+package watch
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/fsnotify/fsnotify"
+	gogitignore "github.com/go-git/go-git/v5/plumbing/format/gitignore"
+
+	"github.com/certinia/asist/config"
+	"github.com/certinia/asist/debugger"
+	"github.com/certinia/asist/diff"
+	"github.com/certinia/asist/engine"
+	"github.com/certinia/asist/errorhandler"
+	"github.com/certinia/asist/files/ignore"
+	"github.com/certinia/asist/finding"
+	"github.com/certinia/asist/message"
+	"github.com/certinia/asist/output"
+)
+
+// debounceDelay is the time waited after the last change before scanning the changed files, as editors often write a file in several steps
+const debounceDelay = 200 * time.Millisecond
+
+// Watcher scans the files of a folder again when they change and reports the findings added and resolved by the changes
+type Watcher struct {
+	scanEngine      *engine.Scanner
+	config          *config.Config
+	rootPath        string
+	isRootFile      bool
+	matcher         gogitignore.Matcher
+	writer          io.Writer
+	findingsPerFile map[string][]finding.Finding
+}
+
+/**
+ * NewWatcher - method used to create a watcher of the file or folder at the root path.
+ *	The files and folders ignored by the ignore files are not watched, like they are not scanned.
+ */
+func NewWatcher(scanEngine *engine.Scanner, cfg *config.Config, rootPath string, w io.Writer) (*Watcher, error) {
+	rootPath, err := filepath.Abs(rootPath)
+	if err != nil {
+		return nil, errorhandler.NewUserError(message.GetPathFetchingError(err))
+	}
+	rootInfo, err := os.Stat(rootPath)
+	if err != nil {
+		return nil, errorhandler.NewUserError(message.GetFileReadError(rootPath, err))
+	}
+	matcher, err := ignore.GetIgnoreFilesPatterns(ignore.IgnoreOptions{
+		RootPath:        rootPath,
+		DontGitIgnore:   cfg != nil && cfg.DontGitIgnore,
+		DontForceIgnore: cfg != nil && cfg.DontForceIgnore,
+	})
+	if err != nil {
+		return nil, errorhandler.NewInternalError(message.GetFilesFetchingError(err))
+	}
+	return &Watcher{
+		scanEngine:      scanEngine,
+		config:          cfg,
+		rootPath:        rootPath,
+		isRootFile:      !rootInfo.IsDir(),
+		matcher:         matcher,
+		writer:          w,
+		findingsPerFile: map[string][]finding.Finding{},
+	}, nil
+}
+
+/**
+ * Run - method used to scan all files, then to scan the files again when they change until ctx is done
+ */
+func (wt *Watcher) Run(ctx context.Context) error {
+	if err := wt.scanAll(ctx); err != nil {
+		return err
+	}
+	fsWatcher, err := fsnotify.NewWatcher()
+	if err != nil {
+		return errorhandler.NewInternalError(message.GetWatcherError(err))
+	}
+	defer fsWatcher.Close()
+	if wt.isRootFile {
+		err = fsWatcher.Add(filepath.Dir(wt.rootPath))
+	} else {
+		err = wt.addFolders(fsWatcher, wt.rootPath)
+	}
+	if err != nil {
+		return errorhandler.NewInternalError(message.GetWatcherError(err))
+	}
+	fmt.Fprintf(os.Stderr, "%s\n", message.GetWatchStartedInfo(wt.rootPath))
+
+	changedPaths := map[string]bool{}
+	debounceTimer := time.NewTimer(debounceDelay)
+	debounceTimer.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return nil
+		case event, isOpen := <-fsWatcher.Events:
+			if !isOpen {
+				return nil
+			}
+			if wt.handleEvent(fsWatcher, event, changedPaths) {
+				debounceTimer.Reset(debounceDelay)
+			}
+		case err, isOpen := <-fsWatcher.Errors:
+			if !isOpen {
+				return nil
+			}
+			return errorhandler.NewInternalError(message.GetWatcherError(err))
+		case <-debounceTimer.C:
+			paths := make([]string, 0, len(changedPaths))
+			for path := range changedPaths {
+				paths = append(paths, path)
+			}
+			clear(changedPaths)
+			if err := wt.scanChanges(ctx, paths); err != nil {
+				return err
+			}
+		}
+	}
+}
+
+/**
+ * scanAll - method used to scan all files of the root path and to write their findings
+ */
+func (wt *Watcher) scanAll(ctx context.Context) error {
+	filePaths, _, err := engine.ListFiles(wt.config, []string{wt.rootPath}, "")
+	if err != nil {
+		return err
+	}
+	finalResult, err := wt.scanEngine.ScanFiles(ctx, filePaths)
+	if err != nil {
+		return ignoreCanceled(err)
+	}
+	for _, result := range finalResult.Results {
+		wt.findingsPerFile[result.Occurrence.FileName] = append(wt.findingsPerFile[result.Occurrence.FileName], result)
+	}
+	output.ReportDiagnostics(os.Stderr, finalResult.Diagnostics)
+	output.DisplayTextOutput(wt.writer, finalResult)
+	return nil
+}
+
+/**
+ * scanChanges - method used to scan the changed files again and to write the findings added and resolved since their previous scan.
+ *	Deleted files have no findings anymore.
+ */
+func (wt *Watcher) scanChanges(ctx context.Context, paths []string) error {
+	sort.Strings(paths)
+	finalResult, err := wt.scanEngine.ScanFiles(ctx, paths)
+	if err != nil {
+		return ignoreCanceled(err)
+	}
+	newFindingsPerFile := map[string][]finding.Finding{}
+	for _, result := range finalResult.Results {
+		newFindingsPerFile[result.Occurrence.FileName] = append(newFindingsPerFile[result.Occurrence.FileName], result)
+	}
+	added, resolved := []finding.Finding{}, []finding.Finding{}
+	for _, path := range paths {
+		// Findings are matched by fingerprint so findings which only moved are neither added nor resolved
+		fileDiff := diff.Compare(wt.findingsPerFile[path], newFindingsPerFile[path])
+		added = append(added, fileDiff.New...)
+		resolved = append(resolved, fileDiff.Fixed...)
+		if len(newFindingsPerFile[path]) == 0 {
+			delete(wt.findingsPerFile, path)
+		} else {
+			wt.findingsPerFile[path] = newFindingsPerFile[path]
+		}
+	}
+	debugger.Debug(fmt.Sprintf("scanned %d changed file(s)", len(paths)))
+	output.ReportDiagnostics(os.Stderr, finalResult.Diagnostics)
+	if len(added) > 0 || len(resolved) > 0 {
+		output.DisplayWatchChanges(wt.writer, time.Now(), added, resolved)
+	}
+	return nil
+}
+
+/**
+ * handleEvent - method used to watch the folders created and to collect the files changed.
+ *	Returns true if the event changed a file to scan.
+ */
+func (wt *Watcher) handleEvent(fsWatcher *fsnotify.Watcher, event fsnotify.Event, changedPaths map[string]bool) bool {
+	if event.Has(fsnotify.Chmod) && !event.Has(fsnotify.Write) && !event.Has(fsnotify.Create) {
+		return false
+	}
+	info, err := os.Stat(event.Name)
+	if err == nil && info.IsDir() {
+		if event.Has(fsnotify.Create) && !wt.isRootFile && wt.isWatched(event.Name, true) {
+			// Files created with the folder, before it is watched, are listed when it is added
+			if err := wt.addFolders(fsWatcher, event.Name); err != nil {
+				debugger.Debug(fmt.Sprintf("could not watch folder %s: %v", event.Name, err))
+			}
+			wt.addFolderFiles(event.Name, changedPaths)
+			return true
+		}
+		return false
+	}
+	// Removed folders are reported like removed files, the findings of their files are resolved
+	if err != nil && !wt.isRootFile {
+		for path := range wt.findingsPerFile {
+			if strings.HasPrefix(path, event.Name+string(filepath.Separator)) {
+				changedPaths[path] = true
+			}
+		}
+	}
+	if !wt.isWatched(event.Name, false) {
+		return len(changedPaths) > 0
+	}
+	changedPaths[event.Name] = true
+	return true
+}
+
+/**
+ * addFolders - method used to watch a folder and its sub folders, except the ignored ones
+ */
+func (wt *Watcher) addFolders(fsWatcher *fsnotify.Watcher, folderPath string) error {
+	return filepath.WalkDir(folderPath, func(path string, entry fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		if !entry.IsDir() {
+			return nil
+		}
+		if !wt.isWatched(path, true) {
+			return filepath.SkipDir
+		}
+		return fsWatcher.Add(path)
+	})
+}
+
+/**
+ * addFolderFiles - method used to collect the files of a folder, e.g. a folder moved into the root path
+ */
+func (wt *Watcher) addFolderFiles(folderPath string, changedPaths map[string]bool) {
+	filepath.WalkDir(folderPath, func(path string, entry fs.DirEntry, err error) error {
+		if err != nil {
+			return nil
+		}
+		if entry.IsDir() && !wt.isWatched(path, true) {
+			return filepath.SkipDir
+		}
+		if !entry.IsDir() && wt.isWatched(path, false) {
+			changedPaths[path] = true
+		}
+		return nil
+	})
+}
+
+/**
+ * isWatched - method used to check if a file or folder is scanned, i.e. it is not ignored by the ignore files nor excluded by the config
+ */
+func (wt *Watcher) isWatched(path string, isDir bool) bool {
+	if wt.isRootFile {
+		return path == wt.rootPath
+	}
+	relPath, err := filepath.Rel(wt.rootPath, path)
+	if err != nil || strings.HasPrefix(relPath, "..") {
+		return false
+	}
+	if relPath == "." {
+		return true
+	}
+	if wt.matcher != nil && wt.matcher.Match(strings.Split(filepath.ToSlash(relPath), "/"), isDir) {
+		return false
+	}
+	return isDir || len(wt.config.FilterExcludedFilesAndFolders([]string{path})) > 0
+}
+
+/**
+ * ignoreCanceled - method used to stop watching without error when the scan is canceled, e.g. on interrupt
+ */
+func ignoreCanceled(err error) error {
+	if errors.Is(err, context.Canceled) {
+		return nil
+	}
+	return err
+}
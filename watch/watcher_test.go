@@ -0,0 +1,88 @@
+package watch
+
+import (
+	"bytes"
+	"context"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/certinia/asist/engine"
+	"github.com/certinia/asist/rules"
+)
+
+func writeFile(t *testing.T, path string, content string) {
+	if err := os.MkdirAll(filepath.Dir(path), 0750); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(path, []byte(content), 0600); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func createWatcher(t *testing.T, rootPath string, w *bytes.Buffer) *Watcher {
+	scanEngine, err := engine.NewScanner(nil, engine.ScanOptions{Rules: []rules.RuleID{"ApexClassNoSharing"}, Jobs: 1})
+	if err != nil {
+		t.Fatalf("Should not return any error! %v", err)
+	}
+	watcher, err := NewWatcher(scanEngine, nil, rootPath, w)
+	if err != nil {
+		t.Fatalf("Should not return any error! %v", err)
+	}
+	return watcher
+}
+
+func TestIsWatched_WhenPathIsIgnored_ReturnsFalse(t *testing.T) {
+	//Given
+	rootPath := t.TempDir()
+	writeFile(t, filepath.Join(rootPath, ".gitignore"), "generated/\n")
+	watcher := createWatcher(t, rootPath, &bytes.Buffer{})
+
+	//When
+	isSfdxWatched := watcher.isWatched(filepath.Join(rootPath, ".sfdx"), true)
+	isSfdxFileWatched := watcher.isWatched(filepath.Join(rootPath, ".sfdx", "Foo.cls"), false)
+	isGitignoredWatched := watcher.isWatched(filepath.Join(rootPath, "generated"), true)
+	isClassWatched := watcher.isWatched(filepath.Join(rootPath, "classes", "Foo.cls"), false)
+
+	//Then
+	if isSfdxWatched || isSfdxFileWatched || isGitignoredWatched {
+		t.Errorf("Ignored paths should not be watched")
+	}
+	if !isClassWatched {
+		t.Errorf("Files which are not ignored should be watched")
+	}
+}
+
+func TestScanChanges_WhenFilesChanged_WritesAddedAndResolvedFindings(t *testing.T) {
+	//Given
+	rootPath := t.TempDir()
+	fixedPath, changedPath := filepath.Join(rootPath, "Fixed.cls"), filepath.Join(rootPath, "Changed.cls")
+	writeFile(t, fixedPath, "public class Fixed {\n}\n")
+	writeFile(t, changedPath, "public with sharing class Changed {\n}\n")
+	var buf bytes.Buffer
+	watcher := createWatcher(t, rootPath, &buf)
+	if err := watcher.scanAll(context.Background()); err != nil {
+		t.Fatalf("Should not return any error! %v", err)
+	}
+	buf.Reset()
+	writeFile(t, fixedPath, "public with sharing class Fixed {\n}\n")
+	writeFile(t, changedPath, "public class Changed {\n}\n")
+
+	//When
+	err := watcher.scanChanges(context.Background(), []string{fixedPath, changedPath})
+
+	//Then
+	if err != nil {
+		t.Fatalf("Should not return any error! %v", err)
+	}
+	actualOutput := buf.String()
+	if !strings.Contains(actualOutput, "1 finding(s) added, 1 finding(s) resolved") ||
+		!strings.Contains(actualOutput, "+ "+changedPath+":1:1: Medium ApexClassNoSharing") ||
+		!strings.Contains(actualOutput, "- "+fixedPath+":1:1: Medium ApexClassNoSharing") {
+		t.Errorf("Expected the finding of Changed.cls added and the finding of Fixed.cls resolved. Actual:\n%s", actualOutput)
+	}
+	if len(watcher.findingsPerFile[fixedPath]) != 0 || len(watcher.findingsPerFile[changedPath]) != 1 {
+		t.Errorf("Findings per file should be updated. Actual: %+v", watcher.findingsPerFile)
+	}
+}